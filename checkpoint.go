@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import "github.com/issue9/localeutil"
+
+// Checkpoint 由 Validation.Checkpoint 返回的检查点
+type Checkpoint struct {
+	v     *Validation
+	sizes map[string]int
+}
+
+// Checkpoint 记录当前错误信息的状态
+//
+// 之后可以通过 Rollback 丢弃在此之后产生的所有错误信息，
+// 可用于尝试性的验证，比如多组字段中只要有一组完全验证通过即可：
+//
+//	cp := v.Checkpoint()
+//	v.NewField(o.Mobile, "mobile", mobileRules...)
+//	if v.ChangedSince(cp) { // 手机号码验证失败，改为验证邮箱和密码
+//	    v.Rollback(cp).
+//	        NewField(o.Email, "email", emailRules...).
+//	        NewField(o.Password, "password", passwordRules...)
+//	}
+func (v *Validation) Checkpoint() *Checkpoint {
+	sizes := make(map[string]int, len(v.messages))
+	for key, msgs := range v.messages {
+		sizes[key] = len(msgs)
+	}
+	return &Checkpoint{v: v, sizes: sizes}
+}
+
+// ChangedSince 在检查点 cp 之后是否产生了新的错误信息
+func (v *Validation) ChangedSince(cp *Checkpoint) bool {
+	v.checkCheckpoint(cp)
+
+	for key, msgs := range v.messages {
+		if len(msgs) > cp.sizes[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// Rollback 丢弃检查点 cp 之后产生的所有错误信息
+//
+// cp 必须是由当前对象的 Checkpoint 返回的，且在 cp 之后不能调用 Messages().Set 等修改已有错误信息的方法。
+func (v *Validation) Rollback(cp *Checkpoint) *Validation {
+	v.checkCheckpoint(cp)
+
+	for key, msgs := range v.messages {
+		size, found := cp.sizes[key]
+		switch {
+		case !found:
+			delete(v.messages, key)
+		case size < len(msgs):
+			v.messages[key] = msgs[:size]
+		}
+	}
+	return v
+}
+
+func (v *Validation) checkCheckpoint(cp *Checkpoint) {
+	if cp.v != v {
+		panic("参数 cp 并不属于当前对象")
+	}
+}
+
+// Child 声明一个子验证对象
+//
+// 子对象继承当前对象的所有设置以及当前路径，但是拥有独立的错误信息，
+// 在子对象上声明的字段与直接在当前对象上声明的字段，其路径是相同的。
+// 之后可以通过 Merge 将子对象的错误信息合并到当前对象，或是直接丢弃子对象：
+//
+//	person := v.Child().NewField(o.IDCard, "id_card", idCardRules...)
+//	company := v.Child().NewField(o.CreditCode, "credit_code", creditCodeRules...)
+//	if !person.Messages().Empty() && !company.Messages().Empty() {
+//	    v.Merge(person).Merge(company)
+//	}
+func (v *Validation) Child() *Validation {
+	child := *v
+	child.messages = make(Messages, 5)
+
+	if v.labels != nil { // 子对象中指定的显示名称不应该影响当前对象
+		child.labels = make(map[string]localeutil.LocaleStringer, len(v.labels))
+		for key, label := range v.labels {
+			child.labels[key] = label
+		}
+	}
+
+	if v.visited != nil {
+		child.visited = make(map[visitKey]struct{}, len(v.visited))
+		for key := range v.visited {
+			child.visited[key] = struct{}{}
+		}
+	}
+
+	return &child
+}
+
+// Merge 将子对象 child 的错误信息合并到当前对象
+//
+// child 一般为由 Child 返回的对象。
+func (v *Validation) Merge(child *Validation) *Validation {
+	v.messages.Merge(child.messages)
+	return v
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"github.com/issue9/localeutil"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/validator"
+)
+
+func TestValidation_Checkpoint(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	required := NewRule(validator.Required(false), "required")
+	email := NewRule(validator.Email, "email")
+
+	v := New(ContinueAtError, 10).NewField("", "name", required)
+	cp := v.Checkpoint()
+	a.False(v.ChangedSince(cp))
+
+	v.NewField("", "name", email).NewField("", "mobile", required)
+	a.True(v.ChangedSince(cp))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"name":   {"required", "email"},
+		"mobile": {"required"},
+	})
+
+	a.False(v.Rollback(cp).ChangedSince(cp))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"name": {"required"},
+	})
+
+	// 回滚之后可继续添加
+	v.NewField("invalid", "email", email)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"name":  {"required"},
+		"email": {"email"},
+	})
+
+	// ExitAtError 在回滚之后可继续验证
+	v = New(ExitAtError, 10)
+	cp = v.Checkpoint()
+	v.NewField("", "mobile", required)
+	a.True(v.ChangedSince(cp))
+	v.Rollback(cp).NewField("", "email", required)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"email": {"required"},
+	})
+
+	a.PanicString(func() {
+		New(ContinueAtError, 10).Rollback(v.Checkpoint())
+	}, "参数 cp 并不属于当前对象")
+}
+
+func TestValidation_Child(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	required := NewRule(validator.Required(false), "%s required", Label)
+
+	validate := func(idCard, creditCode string) LocaleMessages {
+		return New(ContinueAtError, 10, WithPath(SlashPath)).
+			Label("obj/id_card", localeutil.Phrase("ID")).
+			Nested("obj", func(v *Validation) {
+				person := v.Child().NewField(idCard, "id_card", required)
+				company := v.Child().
+					Label("credit_code", localeutil.Phrase("Code")).
+					Label("id_card", localeutil.Phrase("Changed")).
+					NewField(creditCode, "credit_code", required)
+				if !person.Messages().Empty() && !company.Messages().Empty() {
+					v.Merge(person).Merge(company)
+				}
+				v.NewField("", "id_card", required)
+			}).LocaleMessages(p)
+	}
+
+	a.Equal(validate("", ""), LocaleMessages{
+		"obj/id_card":     {"ID required", "ID required"},
+		"obj/credit_code": {"Code required"},
+	})
+	a.Equal(validate("1", ""), LocaleMessages{
+		"obj/id_card": {"ID required"},
+	})
+	a.Equal(validate("", "1"), LocaleMessages{
+		"obj/id_card": {"ID required"},
+	})
+
+	// 子对象继承父对象的错误处理方式
+	v := New(ExitAtError, 10)
+	child := v.Child().NewField("", "f1", required).NewField("", "f2", required)
+	a.Equal(child.LocaleMessages(p), LocaleMessages{
+		"f1": {"f1 required"},
+	})
+	a.Empty(v.Messages())
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+// Condition 条件验证的结果
+//
+// 由 If、Unless、WhenFunc 和 Switch 等方法返回，
+// 除了可以继续调用 Validation 的方法之外，还可以通过 Else 指定条件不满足时的验证。
+type Condition struct {
+	*Validation
+	matched bool
+}
+
+// When 只有满足 cond 才执行 f 中的验证
+//
+// f 中的 v 即为当前对象；
+// 如果需要指定条件不满足时的验证，可以使用 If。
+func (v *Validation) When(cond bool, f func(v *Validation)) *Validation {
+	return v.If(cond, f).Validation
+}
+
+// If 只有满足 cond 才执行 f 中的验证
+//
+// 与 When 相同，但是返回 *Condition，可以通过 Else 指定条件不满足时的验证：
+//
+//	v.If(o.Type == "person", func(v *Validation) {
+//	    v.NewField(o.IDCard, "id_card", rules...)
+//	}).Else(func(v *Validation) {
+//	    v.NewField(o.CreditCode, "credit_code", rules...)
+//	})
+func (v *Validation) If(cond bool, f func(v *Validation)) *Condition {
+	if cond {
+		f(v)
+	}
+	return &Condition{Validation: v, matched: cond}
+}
+
+// Unless 只有不满足 cond 才执行 f 中的验证
+//
+// 相当于 If(!cond, f)。
+func (v *Validation) Unless(cond bool, f func(v *Validation)) *Condition {
+	return v.If(!cond, f)
+}
+
+// WhenFunc 只有 cond 返回 true 才执行 f 中的验证
+//
+// 与 When 不同，cond 在执行到此处时才会被调用，
+// 可以根据已经产生的错误信息决定是否需要验证，比如：
+//
+//	v.NewField(o.Province, "province", rules...).
+//	    WhenFunc(func(v *Validation) bool { return v.Valid("province") }, func(v *Validation) {
+//	        v.NewField(o.City, "city", rules...)
+//	    })
+func (v *Validation) WhenFunc(cond func(v *Validation) bool, f func(v *Validation)) *Condition {
+	return v.If(cond(v), f)
+}
+
+// Switch 根据 val 的值从 cases 中选择对应的验证
+//
+// 如果 cases 中不存在 val 对应的项，则不执行任何验证，此时可以通过 Else 指定默认的验证；
+// val 为切片等不可比较的值时，也被当作不存在对应的项。
+func (v *Validation) Switch(val any, cases map[any]func(v *Validation)) *Condition {
+	f := lookupCase(cases, val)
+	return v.If(f != nil, func(v *Validation) { f(v) })
+}
+
+// 从 cases 中查找 val 对应的验证
+//
+// 包含切片等不可比较的值时，即使 val 的类型本身是可比较的，作为 map 的键名也会 panic，
+// 此时当作不存在对应的项。
+func lookupCase(cases map[any]func(*Validation), val any) (f func(*Validation)) {
+	defer func() {
+		if recover() != nil {
+			f = nil
+		}
+	}()
+	return cases[val]
+}
+
+// Else 在之前的条件不满足时执行 f 中的验证
+func (c *Condition) Else(f func(v *Validation)) *Validation {
+	if !c.matched {
+		f(c.Validation)
+	}
+	return c.Validation
+}
+
+// Valid 字段 name 是否没有错误信息
+//
+// name 为相对于当前路径的字段名称，只判断该字段本身，并不包含其子字段。
+func (v *Validation) Valid(name string) bool {
+	return !v.messages.Has(v.fieldName(name))
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/validator"
+)
+
+func TestValidation_When(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	min18 := NewRule(validator.Min(18), "不能小于 18")
+	notEmpty := NewRule(validator.Required(true), "不能为空")
+
+	obj := &object{}
+	v := New(ContinueAtError, 1).
+		NewField(obj, "obj/age", min18).
+		When(obj.Age > 0, func(v *Validation) {
+			v.NewField(obj.Name, "obj/name", notEmpty)
+		})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"obj/age": {"不能小于 18"},
+	})
+
+	obj = &object{Age: 15}
+	v = New(ContinueAtError, 1).
+		NewField(obj, "obj/age", min18).
+		When(obj.Age > 0, func(v *Validation) {
+			v.NewField(obj.Name, "obj/name", notEmpty)
+		})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"obj/age":  {"不能小于 18"},
+		"obj/name": {"不能为空"},
+	})
+
+	// 返回值依然是 *Validation
+	var when func(bool, func(*Validation)) *Validation = v.When
+	a.Equal(when(false, nil), v)
+}
+
+func TestValidation_Unless(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	notEmpty := NewRule(validator.Required(true), "不能为空")
+
+	v := New(ContinueAtError, 1).
+		Unless(true, func(v *Validation) {
+			v.NewField("", "f1", notEmpty)
+		}).
+		Unless(false, func(v *Validation) {
+			v.NewField("", "f2", notEmpty)
+		})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f2": {"不能为空"},
+	})
+}
+
+func TestCondition_Else(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	notEmpty := NewRule(validator.Required(true), "不能为空")
+
+	v := New(ContinueAtError, 1).
+		If(true, func(v *Validation) {
+			v.NewField("", "f1", notEmpty)
+		}).Else(func(v *Validation) {
+		v.NewField("", "f2", notEmpty)
+	}).
+		If(false, func(v *Validation) {
+			v.NewField("", "f3", notEmpty)
+		}).Else(func(v *Validation) {
+		v.NewField("", "f4", notEmpty)
+	})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f1": {"不能为空"},
+		"f4": {"不能为空"},
+	})
+}
+
+func TestValidation_WhenFunc(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	notEmpty := NewRule(validator.Required(true), "不能为空")
+
+	validate := func(province, city string) LocaleMessages {
+		return New(ContinueAtError, 1).
+			Nested("addr", func(v *Validation) {
+				v.NewField(province, "province", notEmpty).
+					WhenFunc(func(v *Validation) bool { return v.Valid("province") }, func(v *Validation) {
+						v.NewField(city, "city", notEmpty)
+					})
+			}).LocaleMessages(p)
+	}
+
+	a.Equal(validate("", ""), LocaleMessages{
+		"addr.province": {"不能为空"},
+	})
+	a.Equal(validate("p", ""), LocaleMessages{
+		"addr.city": {"不能为空"},
+	})
+	a.Empty(validate("p", "c"))
+}
+
+func TestValidation_Switch(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	notEmpty := NewRule(validator.Required(true), "不能为空")
+
+	validate := func(typ string) LocaleMessages {
+		return New(ContinueAtError, 1).
+			Switch(typ, map[any]func(*Validation){
+				"person": func(v *Validation) {
+					v.NewField("", "id_card", notEmpty)
+				},
+				"company": func(v *Validation) {
+					v.NewField("", "credit_code", notEmpty)
+				},
+			}).Else(func(v *Validation) {
+			v.NewField(typ, "type", NewRule(validator.In("person", "company"), "无效的类型"))
+		}).LocaleMessages(p)
+	}
+
+	a.Equal(validate("person"), LocaleMessages{
+		"id_card": {"不能为空"},
+	})
+	a.Equal(validate("company"), LocaleMessages{
+		"credit_code": {"不能为空"},
+	})
+	a.Equal(validate("other"), LocaleMessages{
+		"type": {"无效的类型"},
+	})
+
+	// 不可比较的值
+	v := New(ContinueAtError, 1).
+		Switch([]string{"person"}, map[any]func(*Validation){
+			"person": func(v *Validation) {
+				v.NewField("", "id_card", notEmpty)
+			},
+		}).Else(func(v *Validation) {
+		v.NewField("", "type", notEmpty)
+	})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"type": {"不能为空"},
+	})
+
+	// 类型可比较，但实际的值不可比较
+	a.NotPanic(func() {
+		v = New(ContinueAtError, 1).
+			Switch([1]any{[]int{1}}, map[any]func(*Validation){
+				"person": func(v *Validation) {
+					v.NewField("", "id_card", notEmpty)
+				},
+			}).Else(func(v *Validation) {
+			v.NewField("", "type", notEmpty)
+		})
+	})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"type": {"不能为空"},
+	})
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+
+	"golang.org/x/text/message"
+)
+
+// NewConvertRule 声明对字段值进行类型转换的规则
+//
+// conv 用于转换字段的值，转换后的值将传递给之后的规则，
+// 如果转换失败，则以 key 和 v 作为错误信息，并跳过该字段之后的所有规则。
+func NewConvertRule(conv func(any) (any, bool), key message.Reference, v ...any) *Rule {
+	r := NewRule(nil, key, v...)
+	r.transform = conv
+	return r
+}
+
+// ParseInt 将字符串转换为 int 的规则
+//
+// 可转换 string、[]byte、[]rune 和 *string 类型的值，本身即为 int 类型的值将原样传递给之后的规则；
+// 其它整数和浮点数类型的值，只要能无损地表示为 int，比如 int8(2) 和 2.0，也会被转换。
+// key 和 v 为转换失败时的错误信息。
+func ParseInt(key message.Reference, v ...any) *Rule {
+	return NewConvertRule(parser(func(s string) (int, error) {
+		i, err := strconv.ParseInt(s, 10, strconv.IntSize)
+		return int(i), err
+	}, true), key, v...)
+}
+
+// ParseUint 将字符串转换为 uint 的规则
+//
+// 其它与 ParseInt 相同。
+func ParseUint(key message.Reference, v ...any) *Rule {
+	return NewConvertRule(parser(func(s string) (uint, error) {
+		i, err := strconv.ParseUint(s, 10, strconv.IntSize)
+		return uint(i), err
+	}, true), key, v...)
+}
+
+// ParseFloat 将字符串转换为 float64 的规则
+//
+// 其它与 ParseInt 相同。
+func ParseFloat(key message.Reference, v ...any) *Rule {
+	return NewConvertRule(parser(func(s string) (float64, error) {
+		return strconv.ParseFloat(s, 64)
+	}, true), key, v...)
+}
+
+// ParseBool 将字符串转换为 bool 的规则
+//
+// 可接受的值可参考 strconv.ParseBool，其它与 ParseInt 相同。
+func ParseBool(key message.Reference, v ...any) *Rule {
+	return NewConvertRule(parser(strconv.ParseBool, false), key, v...)
+}
+
+// ParseTime 将字符串按 layout 的格式转换为 time.Time 的规则
+//
+// layout 的格式可参考 time.Parse，其它与 ParseInt 相同。
+func ParseTime(layout string, key message.Reference, v ...any) *Rule {
+	return NewConvertRule(parser(func(s string) (time.Time, error) {
+		return time.Parse(layout, s)
+	}, false), key, v...)
+}
+
+// ParseDuration 将字符串转换为 time.Duration 的规则
+//
+// 可接受的格式可参考 time.ParseDuration，其它与 ParseInt 相同。
+func ParseDuration(key message.Reference, v ...any) *Rule {
+	return NewConvertRule(parser(time.ParseDuration, false), key, v...)
+}
+
+// numeric 表示是否将其它数值类型的值转换为 T
+func parser[T any](parse func(string) (T, error), numeric bool) func(any) (any, bool) {
+	return func(v any) (any, bool) {
+		var s string
+		switch vv := v.(type) {
+		case T:
+			return vv, true
+		case string:
+			s = vv
+		case []byte:
+			s = string(vv)
+		case []rune:
+			s = string(vv)
+		case *string:
+			if vv == nil {
+				return nil, false
+			}
+			s = *vv
+		default:
+			if numeric {
+				return convertNumber[T](v)
+			}
+			return nil, false
+		}
+
+		val, err := parse(s)
+		if err != nil {
+			return nil, false
+		}
+		return val, true
+	}
+}
+
+// 将数值 v 转换为同为数值类型的 T，无法无损转换的值返回 false。
+func convertNumber[T any](v any) (any, bool) {
+	rv := reflect.ValueOf(v)
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if !isNumber(rv.Kind()) || !isNumber(t.Kind()) {
+		return nil, false
+	}
+
+	// 转换之后再转换回来，值不变且符号不变，才表示是无损的转换。
+	val := rv.Convert(t)
+	if val.Convert(rv.Type()).Interface() != rv.Interface() || isNegative(val) != isNegative(rv) {
+		return nil, false
+	}
+	return val.Interface(), true
+}
+
+func isNumber(k reflect.Kind) bool { return k >= reflect.Int && k <= reflect.Float64 }
+
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	default:
+		return false
+	}
+}
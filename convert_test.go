@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/filter"
+	"github.com/issue9/validation/validator"
+)
+
+func TestParse(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	in := NewRule(validator.In(1, 2, 3), "in")
+	min5 := NewRule(validator.Min(5), "min-5")
+	after2000 := NewRule(validator.ValidateFunc(func(v any) bool {
+		return v.(time.Time).After(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	}), "after-2000")
+
+	str := "2"
+	var nilStr *string
+	v := New(ContinueAtError, 10).
+		NewField("2", "int1", ParseInt("int"), in).
+		NewField("x", "int2", ParseInt("int"), in).
+		NewField(5, "int3", ParseInt("int"), in).
+		NewField(&str, "int4", ParseInt("int"), in).
+		NewField(nilStr, "int5", ParseInt("int"), in).
+		NewField(int8(2), "int6", ParseInt("int"), in).
+		NewField(2.0, "int7", ParseInt("int"), in).
+		NewField(2.5, "int8", ParseInt("int"), in).
+		NewField(int8(-1), "uint3", ParseUint("uint"), min5).
+		NewField(uint8(6), "uint4", ParseUint("uint"), min5).
+		NewField(int32(6), "float3", ParseFloat("float"), min5).
+		NewField([]byte("6"), "uint1", ParseUint("uint"), min5).
+		NewField("-6", "uint2", ParseUint("uint"), min5).
+		NewField([]rune("5.5"), "float1", ParseFloat("float"), min5).
+		NewField("4.5", "float2", ParseFloat("float"), min5).
+		NewField("true", "bool1", ParseBool("bool"), NewRule(validator.In(true), "true")).
+		NewField("yes", "bool2", ParseBool("bool")).
+		NewField("2001-01-02", "time1", ParseTime("2006-01-02", "time"), after2000).
+		NewField("1999-01-02", "time2", ParseTime("2006-01-02", "time"), after2000).
+		NewField("1999/01/02", "time3", ParseTime("2006-01-02", "time"), after2000).
+		NewField("1h", "duration1", ParseDuration("duration"), NewRule(validator.In(time.Hour), "1h")).
+		NewField("1x", "duration2", ParseDuration("duration"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"int2":      {"int"},
+		"int3":      {"in"},
+		"int5":      {"int"},
+		"int8":      {"int"},
+		"uint2":     {"uint"},
+		"uint3":     {"uint"},
+		"float2":    {"min-5"},
+		"bool2":     {"bool"},
+		"time2":     {"after-2000"},
+		"time3":     {"time"},
+		"duration2": {"duration"},
+	})
+
+	// 与 Filter 和 OmitEmpty 配合使用
+	v = New(ContinueAtError, 10).
+		NewField(" １ ", "f1", Filter(filter.HalfWidth, filter.Trim), ParseInt("int"), in).
+		NewField("", "f2", OmitEmpty(), ParseInt("int"), in)
+	a.Empty(v.Messages())
+
+	// 即使是 ContinueAtError，也不会继续验证转换失败的字段
+	v = New(ContinueAtError, 10).
+		NewField("x", "f1", ParseInt("int"), in, min5)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f1": {"int"},
+	})
+
+	// 类型不匹配时，不会使用转换规则的错误信息
+	v = New(ContinueAtError, 10).NewSliceField(5, "slice", ParseInt("int"), in)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"slice": {"in"},
+	})
+}
+
+func TestNewConvertRule(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	length := NewConvertRule(func(v any) (any, bool) {
+		s, ok := v.(string)
+		return len(s), ok
+	}, "string")
+
+	v := New(ContinueAtError, 10).
+		NewField("123456", "f1", length, NewRule(validator.Min(5), "min-5")).
+		NewField("1234", "f2", length, NewRule(validator.Min(5), "min-5")).
+		NewField(5, "f3", length, NewRule(validator.Min(5), "min-5"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f2": {"min-5"},
+		"f3": {"string"},
+	})
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// NewPathField 验证 doc 中与 pattern 相匹配的所有值
+//
+// doc 一般为从 JSON 等数据中解码而来的 map[string]any 或 []any，也可以是其它键名为字符串的 map、数组和切片；
+// pattern 为以点号分隔的字段路径，比如 user.address.city，其中：
+//   - 对于 map，以键名匹配，* 表示匹配所有的键名，按键名顺序依次验证；
+//   - 对于数组和切片，以下标匹配，* 表示匹配所有的元素；
+//
+// 每一个匹配的值都会以 rules 进行验证，错误信息以该值的实际路径作为名称，比如 items.*.price 可能产生
+// items[0].price 和 items[1].price 等，具体格式由 WithPath 决定，map 的键名与结构体的字段采用相同的格式。
+//
+// 路径中不包含 * 的部分如果不存在，则以 nil 作为该值进行验证，以便 validator.Required 等规则可以生效；
+// * 匹配的是一个空的或不存在的集合时，则不会产生任何值。
+func (v *Validation) NewPathField(doc any, pattern string, rules ...*Rule) *Validation {
+	if v.exited() {
+		return v
+	}
+
+	var segments []string
+	if pattern != "" {
+		segments = strings.Split(pattern, ".")
+	}
+	v.validatePath(reflect.ValueOf(doc), v.prefix, segments, rules)
+	return v
+}
+
+// 以 segments 匹配 rv 中的值并进行验证，返回值表示是否需要继续验证。
+func (v *Validation) validatePath(rv reflect.Value, name string, segments []string, rules []*Rule) bool {
+	if len(segments) == 0 {
+		if v.absent(name) {
+			return true
+		}
+
+		var val any
+		if rv.IsValid() {
+			val = rv.Interface()
+		}
+		return v.validate(val, name, rules) || v.errHandling == ContinueAtError
+	}
+
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+
+	seg, segments := segments[0], segments[1:]
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+
+		if seg != "*" {
+			return v.validatePath(rv.MapIndex(reflect.ValueOf(seg).Convert(rv.Type().Key())), v.path.Field(name, seg), segments, rules)
+		}
+
+		for _, key := range sortMapKeys(rv) {
+			if !v.validatePath(rv.MapIndex(key), v.path.Field(name, key.String()), segments, rules) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array, reflect.Slice:
+		if seg != "*" {
+			index, err := strconv.Atoi(seg)
+			if err != nil || index < 0 || index >= rv.Len() {
+				break
+			}
+			return v.validatePath(rv.Index(index), v.path.Index(name, index), segments, rules)
+		}
+
+		for i := 0; i < rv.Len(); i++ {
+			if !v.validatePath(rv.Index(i), v.path.Index(name, i), segments, rules) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if seg == "*" {
+		return true
+	}
+
+	// 不存在的字段，以 nil 继续匹配
+	var n string
+	if index, err := strconv.Atoi(seg); err == nil && (rv.Kind() == reflect.Array || rv.Kind() == reflect.Slice) {
+		n = v.path.Index(name, index)
+	} else {
+		n = v.path.Field(name, seg)
+	}
+	return v.validatePath(reflect.Value{}, n, segments, rules)
+}
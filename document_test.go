@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/validator"
+)
+
+func TestValidation_NewPathField(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	var doc map[string]any
+	a.NotError(json.Unmarshal([]byte(`{
+		"user": {"name": "", "address": {"city": "city"}},
+		"items": [{"price": 1}, {"price": -1}, {}, {"price": 0}],
+		"tags": {"t2": "", "t1": "v1", "t3": null},
+		"ids": [1, 2]
+	}`), &doc))
+
+	required := NewRule(validator.Required(false), "required")
+	min := NewRule(validator.Min(0), "min")
+
+	v := New(ContinueAtError, 10).
+		NewPathField(doc, "user.name", required).
+		NewPathField(doc, "user.address.city", required).
+		NewPathField(doc, "user.address.zip", required).
+		NewPathField(doc, "not.exists", required).
+		NewPathField(doc, "items.*.price", required, min).
+		NewPathField(doc, "tags.*", required).
+		NewPathField(doc, "ids.1", NewRule(validator.Max(1), "max")).
+		NewPathField(doc, "ids.5", required).
+		NewPathField(doc, "ids.*.id", required).
+		NewPathField(doc, "missing.*", required)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"user.name":        {"required"},
+		"user.address.zip": {"required"},
+		"not.exists":       {"required"},
+		"items[1].price":   {"min"},
+		"items[2].price":   {"required", "min"},
+		"items[3].price":   {"required"},
+		"tags.t2":          {"required"},
+		"tags.t3":          {"required"},
+		"ids[1]":           {"max"},
+		"ids[5]":           {"required"},
+		"ids[0].id":        {"required"},
+		"ids[1].id":        {"required"},
+	})
+
+	v = New(ExitFieldAtError, 10, WithPath(JSONPointer)).
+		NewPathField(doc, "items.*.price", required, min)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"/items/1/price": {"min"},
+	})
+
+	v = New(ExitAtError, 10).
+		NewPathField(doc, "items.*.price", required, min).
+		NewPathField(doc, "user.name", required)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"items[1].price": {"min"},
+	})
+
+	// Nested 和 WithPresentMap
+	v = New(ContinueAtError, 10, WithPresentMap(map[string]any{"obj": doc})).
+		Nested("obj", func(v *Validation) {
+			v.NewPathField(doc, "user.*", required).
+				NewPathField(doc, "user.address.zip", required)
+		})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"obj.user.name": {"required"},
+	})
+
+	// 非 map
+	v = New(ContinueAtError, 10).
+		NewPathField([]map[string]int{{"a": 1}, {"a": 0}}, "*.a", NewRule(validator.Min(1), "min")).
+		NewPathField(5, "", NewRule(validator.Min(10), "min")).
+		NewPathField(map[int]int{1: 1}, "1", required)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"[1].a": {"min"},
+		"":      {"min"},
+		"1":     {"required"},
+	})
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/expr"
+)
+
+// 表达式在某一类型及字段名称设置下的检查结果的键名
+type exprCheckKey struct {
+	e      *expr.Expr
+	typ    reflect.Type
+	tag    string
+	naming uintptr
+}
+
+// 表达式的检查结果，键名为 exprCheckKey，键值为 error。
+var exprChecks sync.Map
+
+// NewExprField 以表达式验证 env
+//
+// env 为表达式的计算对象，可以是结构体或是 map[string]any；
+// 当表达式 e 的计算结果不为 true 时，以 key 和 args 作为字段 name 的错误信息，参数与 NewRule 相同。
+//
+//	e := expr.MustParse(`age >= 18 || guardian != ""`)
+//	v.NewExprField(o, "guardian", e, "未成年人必须填写监护人")
+//
+// 表达式中的结构体字段，可以使用原始的字段名、json 标签中指定的名称以及由 WithFieldName 指定的名称，
+// 一个名称同时匹配了多个字段也被当作错误。
+//
+// 如果 env 为结构体，表达式中存在无法找到的字段会直接 panic，同一类型只会检查一次；
+// 其它的计算错误，比如子字段为 nil，则被当作验证失败。
+func (v *Validation) NewExprField(env any, name string, e *expr.Expr, key message.Reference, args ...any) *Validation {
+	if v.exited() {
+		return v
+	}
+
+	ck := exprCheckKey{e: e, typ: reflect.TypeOf(env), tag: v.nameTag}
+	if v.naming != nil {
+		ck.naming = reflect.ValueOf(v.naming).Pointer()
+	}
+	err, found := exprChecks.Load(ck)
+	if !found {
+		err = e.Check(ck.typ, v.exprNames)
+		exprChecks.Store(ck, err)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("表达式 %s 无效：%s", e, err))
+	}
+
+	return v.newExprField(env, name, e, v.exprNames, key, args...)
+}
+
+func (v *Validation) newExprField(env any, name string, e *expr.Expr, names expr.Names, key message.Reference, args ...any) *Validation {
+	if v.exited() {
+		return v
+	}
+
+	if name = v.fieldName(name); v.absent(name) {
+		return v
+	}
+
+	ret, err := e.EvalWithNames(env, names)
+	if b, ok := ret.(bool); err != nil || !ok || !b {
+		v.addMessage(name, NewRule(e, key, args...))
+	}
+	return v
+}
+
+// 结构体字段在表达式中除原始字段名之外可用的名称
+func (v *Validation) exprNames(f reflect.StructField) []string {
+	names := make([]string, 0, 2)
+	if n, _, _ := strings.Cut(f.Tag.Get("json"), ","); n != "" && n != "-" {
+		names = append(names, n)
+	}
+	if n, _ := v.structFieldName(f); n != "" && n != f.Name {
+		names = append(names, n)
+	}
+	return names
+}
+
+// Expr 声明以表达式进行验证
+//
+// src 为表达式的内容，在声明时即被解析，同时检查其中的标识符是否都是 T 中的字段，
+// 如果格式不正确或是字段不存在，会直接 panic。
+// 声明时无法得知 WithFieldName 的设置，所以表达式中的结构体字段只能使用原始的字段名和 json 标签中指定的名称。
+// 表达式以 *T 作为计算对象，其它参数与 Validation.NewExprField 相同。
+func (s *Schema[T]) Expr(name, src string, key message.Reference, args ...any) *Schema[T] {
+	e := expr.MustParse(src)
+	if err := e.Check(reflect.TypeOf((*T)(nil)), nil); err != nil {
+		panic(fmt.Sprintf("表达式 %s 无效：%s", src, err))
+	}
+	return s.Check(func(v *Validation, obj *T) { v.newExprField(obj, name, e, nil, key, args...) })
+}
@@ -0,0 +1,395 @@
+// SPDX-License-Identifier: MIT
+
+package expr
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/issue9/sliceutil"
+
+	"github.com/issue9/validation/validator"
+)
+
+type (
+	node interface {
+		eval(env reflect.Value, names Names) (any, error)
+	}
+
+	literalNode struct{ val any }
+
+	identNode struct{ path []string }
+
+	listNode struct{ elems []node }
+
+	lenNode struct{ n node }
+
+	callNode struct {
+		validator validator.Validator
+		n         node
+	}
+
+	unaryNode struct {
+		op string
+		n  node
+	}
+
+	binaryNode struct {
+		op          string
+		left, right node
+	}
+)
+
+func (n *literalNode) eval(reflect.Value, Names) (any, error) { return n.val, nil }
+
+func (n *identNode) eval(env reflect.Value, names Names) (any, error) {
+	rv := env
+	for i, name := range n.path {
+		for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+			if rv.IsNil() {
+				return nil, fmt.Errorf("%s 为 nil", strings.Join(n.path[:i], "."))
+			}
+			rv = rv.Elem()
+		}
+
+		switch rv.Kind() {
+		case reflect.Struct:
+			f, err := field(rv.Type(), n.path[:i+1], names)
+			if err != nil {
+				return nil, err
+			}
+
+			if rv, err = rv.FieldByIndexErr(f.Index); err != nil { // 嵌入的结构体指针为 nil
+				return nil, fmt.Errorf("%s 为 nil", strings.Join(n.path[:i+1], "."))
+			}
+		case reflect.Map:
+			if rv.Type().Key().Kind() != reflect.String {
+				return nil, fmt.Errorf("不支持的类型 %s", rv.Type())
+			}
+			rv = rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+			if !rv.IsValid() {
+				return nil, fmt.Errorf("字段 %s 不存在", strings.Join(n.path[:i+1], "."))
+			}
+		default:
+			return nil, fmt.Errorf("无法从类型 %s 中获取字段 %s", rv.Type(), name)
+		}
+	}
+	return normalize(rv), nil
+}
+
+// 检查标识符能否在类型 t 中找到
+func (n *identNode) check(t reflect.Type, names Names) error {
+	for i, name := range n.path {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			f, err := field(t, n.path[:i+1], names)
+			if err != nil {
+				return err
+			}
+			t = f.Type
+		case reflect.Map:
+			if t.Key().Kind() != reflect.String {
+				return fmt.Errorf("不支持的类型 %s", t)
+			}
+			t = t.Elem()
+		case reflect.Interface: // 只有在计算时才能确定
+			return nil
+		default:
+			return fmt.Errorf("无法从类型 %s 中获取字段 %s", t, name)
+		}
+	}
+	return nil
+}
+
+// 从结构体 t 中查找由 path 的最后一项指定的字段
+//
+// 字段的原始名称以及由 names 返回的名称都可以匹配，匹配多个字段时返回错误。
+func field(t reflect.Type, path []string, names Names) (reflect.StructField, error) {
+	if names == nil {
+		names = jsonNames
+	}
+
+	name := path[len(path)-1]
+	var found []reflect.StructField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() {
+			continue
+		}
+		if f.Name == name || sliceutil.Exists(names(f), func(n string) bool { return n == name }) {
+			found = append(found, f)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return reflect.StructField{}, fmt.Errorf("字段 %s 不存在", strings.Join(path, "."))
+	case 1:
+		return found[0], nil
+	default:
+		return reflect.StructField{}, fmt.Errorf("字段 %s 同时匹配了 %s 和 %s", strings.Join(path, "."), found[0].Name, found[1].Name)
+	}
+}
+
+func jsonNames(f reflect.StructField) []string {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		return []string{name}
+	}
+	return nil
+}
+
+func (n *listNode) eval(env reflect.Value, names Names) (any, error) {
+	elems := make([]any, 0, len(n.elems))
+	for _, e := range n.elems {
+		val, err := e.eval(env, names)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, val)
+	}
+	return elems, nil
+}
+
+func (n *lenNode) eval(env reflect.Value, names Names) (any, error) {
+	val, err := n.n.eval(env, names)
+	if err != nil {
+		return nil, err
+	}
+
+	switch vv := val.(type) {
+	case nil:
+		return float64(0), nil
+	case string:
+		return float64(utf8.RuneCountInString(vv)), nil
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return float64(rv.Len()), nil
+	default:
+		return nil, fmt.Errorf("len 不支持类型 %T", val)
+	}
+}
+
+func (n *callNode) eval(env reflect.Value, names Names) (any, error) {
+	val, err := n.n.eval(env, names)
+	if err != nil {
+		return nil, err
+	}
+	return n.validator.IsValid(val), nil
+}
+
+func (n *unaryNode) eval(env reflect.Value, names Names) (any, error) {
+	val, err := n.n.eval(env, names)
+	if err != nil {
+		return nil, err
+	}
+
+	switch vv := val.(type) {
+	case bool:
+		if n.op == "!" {
+			return !vv, nil
+		}
+	case float64:
+		if n.op == "-" {
+			return -vv, nil
+		}
+	}
+	return nil, fmt.Errorf("运算符 %s 不支持类型 %T", n.op, val)
+}
+
+func (n *binaryNode) eval(env reflect.Value, names Names) (any, error) {
+	left, err := n.left.eval(env, names)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("运算符 %s 不支持类型 %T", n.op, left)
+		}
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+
+		right, err := n.right.eval(env, names)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("运算符 %s 不支持类型 %T", n.op, right)
+		}
+		return r, nil
+	}
+
+	right, err := n.right.eval(env, names)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "in":
+		return in(left, right)
+	case "<", "<=", ">", ">=":
+		return compare(n.op, left, right)
+	default:
+		return arithmetic(n.op, left, right)
+	}
+}
+
+// 将反射值转换为表达式中使用的值，所有数值都转换为 float64。
+func normalize(rv reflect.Value) any {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	default:
+		return rv.Interface()
+	}
+}
+
+// 比较 left 和 right 是否相等，无法以 == 比较的值由 reflect.DeepEqual 进行比较。
+func equal(left, right any) (eq bool) {
+	left, right = normalize(reflect.ValueOf(left)), normalize(reflect.ValueOf(right))
+
+	defer func() {
+		if recover() != nil {
+			eq = reflect.DeepEqual(left, right)
+		}
+	}()
+	return left == right
+}
+
+func in(elem, set any) (bool, error) {
+	switch s := set.(type) {
+	case nil:
+		return false, nil
+	case string:
+		e, ok := elem.(string)
+		if !ok {
+			return false, fmt.Errorf("运算符 in 不支持在字符串中查找类型 %T", elem)
+		}
+		return strings.Contains(s, e), nil
+	}
+
+	rv := reflect.ValueOf(set)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			if equal(elem, rv.Index(i).Interface()) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			if equal(elem, iter.Key().Interface()) {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("运算符 in 不支持类型 %T", set)
+	}
+}
+
+func compare(op string, left, right any) (bool, error) {
+	var c int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return false, fmt.Errorf("类型 %T 与 %T 之间无法比较", left, right)
+		}
+		switch {
+		case l < r:
+			c = -1
+		case l > r:
+			c = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return false, fmt.Errorf("类型 %T 与 %T 之间无法比较", left, right)
+		}
+		c = strings.Compare(l, r)
+	default:
+		return false, fmt.Errorf("运算符 %s 不支持类型 %T", op, left)
+	}
+
+	switch op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default: // >=
+		return c >= 0, nil
+	}
+}
+
+func arithmetic(op string, left, right any) (any, error) {
+	if op == "+" {
+		if l, ok := left.(string); ok {
+			if r, ok := right.(string); ok {
+				return l + r, nil
+			}
+		}
+	}
+
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("运算符 %s 不支持类型 %T 与 %T", op, left, right)
+	}
+
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, fmt.Errorf("除数不能为 0")
+		}
+		return l / r, nil
+	default: // %
+		if r == 0 {
+			return nil, fmt.Errorf("除数不能为 0")
+		}
+		return math.Mod(l, r), nil
+	}
+}
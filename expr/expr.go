@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+
+// Package expr 以表达式的形式声明验证规则
+//
+// 表达式的计算对象可以是结构体或是 map[string]any，比如：
+//
+//	age >= 18 || guardian != ""
+//	len(items) <= quota
+//	type in ["person", "company"] && email(contact)
+//
+// 支持以下语法：
+//   - 字面量：数值、以双引号或单引号包含的字符串、true、false、nil 以及 [a, b] 形式的列表；
+//   - 标识符：结构体的字段名或是 map 的键名，可以用点号访问子字段，比如 address.city，
+//     结构体字段除了原始的字段名之外，还可以使用 json 标签中指定的名称，或是由 Names 指定的名称；
+//   - 比较运算：==、!=、<、<=、>、>=，其中大小比较只能用于数值与数值或是字符串与字符串之间；
+//   - 逻辑运算：&&、|| 和 !，操作数必须是布尔值；
+//   - 算术运算：+、-、*、/ 和 %，其中 + 也可用于连接字符串；
+//   - len(x)：返回字符串的字符数量或是数组、切片和 map 的元素数量；
+//   - x in y：y 为列表、数组或切片时判断是否包含元素 x，为 map 时判断是否存在键名 x，为字符串时判断是否包含子串 x；
+//   - 通过 Register 注册的验证器，比如 email(contact)，返回验证结果。
+//
+// 所有的数值都会被转换成 float64 进行计算。
+package expr
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/issue9/validation/validator"
+)
+
+var (
+	funcs = map[string]validator.Validator{
+		"gb32100":   validator.GB32100,
+		"gb11643":   validator.GB11643,
+		"hex_color": validator.HexColor,
+		"bank_card": validator.BankCard,
+		"isbn":      validator.ISBN,
+		"url":       validator.URL,
+		"ip":        validator.IP,
+		"ip4":       validator.IP4,
+		"ip6":       validator.IP6,
+		"email":     validator.Email,
+		"cn_phone":  validator.CNPhone,
+		"cn_mobile": validator.CNMobile,
+		"cn_tel":    validator.CNTel,
+		"unique":    validator.Unique,
+		"sorted":    validator.Sorted,
+		"required":  validator.Required(false),
+	}
+	funcsMux sync.RWMutex
+)
+
+// Expr 解析后的表达式
+//
+// 在解析之后可以在多个 goroutine 中同时使用。
+type Expr struct {
+	src  string
+	root node
+}
+
+// Names 返回结构体字段在表达式中除原始字段名之外可用的名称
+//
+// 字段的原始名称总是可用的，如果一个名称同时匹配了多个字段，则被当作错误处理。
+type Names func(f reflect.StructField) []string
+
+// Register 注册可以在表达式中调用的验证器
+//
+// 如果 name 已经存在，则会覆盖原有的值，包括 len 之外的内置函数。
+// 只对之后解析的表达式有效。
+func Register(name string, v validator.Validator) {
+	if name == "len" {
+		panic("len 为保留的函数名")
+	}
+
+	funcsMux.Lock()
+	defer funcsMux.Unlock()
+	funcs[name] = v
+}
+
+func lookupFunc(name string) (validator.Validator, bool) {
+	funcsMux.RLock()
+	defer funcsMux.RUnlock()
+	v, found := funcs[name]
+	return v, found
+}
+
+// Parse 解析表达式
+func Parse(src string) (*Expr, error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// MustParse 解析表达式，如果出错则 panic
+func MustParse(src string) *Expr {
+	e, err := Parse(src)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Eval 以 env 计算表达式的值
+//
+// env 可以是结构体、结构体指针或是 map[string]any，表达式中的标识符从 env 中获取对应的值，
+// 结构体字段可以使用原始的字段名或是 json 标签中指定的名称。
+func (e *Expr) Eval(env any) (any, error) { return e.EvalWithNames(env, nil) }
+
+// EvalWithNames 以 env 计算表达式的值
+//
+// 与 Eval 相同，但是结构体字段可用的名称由 names 决定，为 nil 时与 Eval 相同。
+func (e *Expr) EvalWithNames(env any, names Names) (any, error) {
+	return e.root.eval(reflect.ValueOf(env), names)
+}
+
+// Check 检查表达式中的标识符是否都能在类型 t 中找到
+//
+// 可用于在计算之前发现拼写错误或是同时匹配多个字段的标识符，names 与 EvalWithNames 中的参数相同。
+// 只能检查结构体中的字段，map 和 interface 等类型的内容只有在计算时才能确定，不作检查。
+func (e *Expr) Check(t reflect.Type, names Names) error {
+	if t == nil {
+		return nil
+	}
+	return walk(e.root, func(n *identNode) error { return n.check(t, names) })
+}
+
+// 依次对 n 中的所有标识符调用 f
+func walk(n node, f func(*identNode) error) error {
+	switch nn := n.(type) {
+	case *identNode:
+		return f(nn)
+	case *listNode:
+		for _, elem := range nn.elems {
+			if err := walk(elem, f); err != nil {
+				return err
+			}
+		}
+	case *lenNode:
+		return walk(nn.n, f)
+	case *callNode:
+		return walk(nn.n, f)
+	case *unaryNode:
+		return walk(nn.n, f)
+	case *binaryNode:
+		if err := walk(nn.left, f); err != nil {
+			return err
+		}
+		return walk(nn.right, f)
+	}
+	return nil
+}
+
+// IsValid 以 v 计算表达式，返回值是否为 true
+//
+// 计算出错或是计算结果不是布尔值，都将返回 false。
+// 实现了 validator.Validator 接口。
+func (e *Expr) IsValid(v any) bool {
+	ret, err := e.Eval(v)
+	if err != nil {
+		return false
+	}
+	b, ok := ret.(bool)
+	return ok && b
+}
+
+func (e *Expr) String() string { return e.src }
+
+type syntaxError struct {
+	pos int
+	msg string
+}
+
+func (err *syntaxError) Error() string { return fmt.Sprintf("位置 %d：%s", err.pos, err.msg) }
@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: MIT
+
+package expr
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+
+	"github.com/issue9/validation/validator"
+)
+
+type (
+	address struct {
+		City string
+	}
+
+	object struct {
+		Age      int
+		Guardian string `json:"guardian,omitempty"`
+		Items    []string
+		Quota    uint8
+		Type     string
+		Contact  string
+		Address  *address
+		Tags     map[string]int
+		private  int
+	}
+)
+
+func TestParse(t *testing.T) {
+	a := assert.New(t, false)
+
+	for _, src := range []string{
+		"age >= 18 || guardian != \"\"",
+		"len(items) <= quota",
+		"type in ['person', 'company'] && email(contact)",
+		"!(a + b * -c % 2 == 1) && a.b.c != nil",
+		"[]",
+		"x in [1, 2.5, 'a\\'b']",
+	} {
+		e, err := Parse(src)
+		a.NotError(err, src).NotNil(e).Equal(e.String(), src)
+	}
+
+	for _, src := range []string{
+		"",
+		"age >=",
+		"(age > 1",
+		"[1, 2",
+		"not_exists(age)",
+		"len(a, b)",
+		"email()",
+		"'abc",
+		"a..b",
+		"a.",
+		"age # 1",
+		"1.2.3",
+		"age 1",
+		")",
+	} {
+		e, err := Parse(src)
+		a.Error(err, src).Nil(e)
+	}
+
+	a.Panic(func() { MustParse("age >=") })
+	a.NotPanic(func() { MustParse("age >= 18") })
+}
+
+func TestExpr_Eval(t *testing.T) {
+	a := assert.New(t, false)
+
+	obj := &object{
+		Age:      15,
+		Guardian: "g",
+		Items:    []string{"1", "2", "3"},
+		Quota:    2,
+		Type:     "person",
+		Contact:  "user@example.com",
+		Address:  &address{City: "city"},
+		Tags:     map[string]int{"t1": 1},
+	}
+	m := map[string]any{
+		"Age":     15,
+		"Items":   []int{1, 2},
+		"Address": map[string]any{"City": "city"},
+		"Pair":    [1]any{[]int{1}},
+	}
+
+	data := []*struct {
+		src string
+		env any
+		val any
+	}{
+		{src: "Age >= 18 || Guardian != \"\"", env: obj, val: true},
+		{src: "Age >= 18 || Guardian == ''", env: obj, val: false},
+		{src: "Age >= 18 || guardian != ''", env: obj, val: true},
+		{src: "len(Items) <= Quota", env: obj, val: false},
+		{src: "len(Items) <= Quota + 1", env: *obj, val: true},
+		{src: "Type in ['person', 'company'] && email(Contact)", env: obj, val: true},
+		{src: "Address.City == 'city'", env: obj, val: true},
+		{src: "Address.City + '-1'", env: obj, val: "city-1"},
+		{src: "'t1' in Tags && !('t2' in Tags)", env: obj, val: true},
+		{src: "'2' in Items", env: obj, val: true},
+		{src: "'it' in Address.City", env: obj, val: true},
+		{src: "len('中文') * 2 - 1", env: obj, val: float64(3)},
+		{src: "Age % 4 / 2", env: obj, val: 1.5},
+		{src: "-Age", env: obj, val: float64(-15)},
+		{src: "1 + 2 * 3 == 7", env: nil, val: true},
+		{src: "(1 + 2) * 3", env: nil, val: float64(9)},
+		{src: "'a' < 'b' && 2 > 1 && 1 <= 1 && 1 >= 2", env: nil, val: false},
+		{src: "len(nil) == 0 && nil == nil && 1 != nil", env: nil, val: true},
+		{src: "1 in nil", env: nil, val: false},
+		{src: "[1, 2] == [1, 2]", env: nil, val: true},
+
+		// map
+		{src: "Age < 18 && len(Items) == 2 && 2 in Items", env: m, val: true},
+		{src: "Address.City", env: m, val: "city"},
+		{src: "Pair == Pair && Pair in [1, Pair]", env: m, val: true},
+	}
+	for _, item := range data {
+		val, err := MustParse(item.src).Eval(item.env)
+		a.NotError(err, item.src).Equal(val, item.val, item.src)
+	}
+
+	for _, src := range []string{
+		"NotExists > 1",
+		"private > 1",
+		"Age.City",
+		"Address.NotExists",
+		"Age > '1'",
+		"Type > 1",
+		"Items > 1",
+		"Age + 'a'",
+		"Age / 0",
+		"Age % 0",
+		"!Age",
+		"-Type",
+		"Age && true",
+		"false || Age",
+		"len(Age)",
+		"1 in 'abc'",
+		"1 in Age",
+		"NotExists == 1 || true",
+	} {
+		_, err := MustParse(src).Eval(obj)
+		a.Error(err, src)
+	}
+
+	_, err := MustParse("Address.City").Eval(&object{})
+	a.Error(err)
+	_, err = MustParse("Age").Eval(map[int]any{1: 1})
+	a.Error(err)
+	_, err = MustParse("NotExists").Eval(m)
+	a.Error(err)
+}
+
+func TestExpr_EvalWithNames(t *testing.T) {
+	a := assert.New(t, false)
+
+	names := func(f reflect.StructField) []string { return []string{strings.ToLower(f.Name)} }
+	obj := &object{Age: 18, Address: &address{City: "city"}}
+
+	val, err := MustParse("age >= 18 && address.city == 'city' && Age == 18").EvalWithNames(obj, names)
+	a.NotError(err).Equal(val, true)
+
+	// 指定了 names 之后，不再使用 json 标签中的名称
+	_, err = MustParse("guardian == ''").EvalWithNames(obj, names)
+	a.NotError(err)
+	_, err = MustParse("guardian == ''").EvalWithNames(obj, func(reflect.StructField) []string { return nil })
+	a.Error(err)
+}
+
+func TestExpr_Check(t *testing.T) {
+	a := assert.New(t, false)
+
+	typ := reflect.TypeOf(&object{})
+	for _, src := range []string{
+		"Age >= 18 || guardian != ''",
+		"len(Items) <= Quota && Address.City == 'city'",
+		"'t1' in Tags && Tags.t1 > 0",
+		"[Age, 1] == [1, 1] && !(Age > 1) && email(Contact) && -Age < len(Type)",
+		"1 + 2",
+	} {
+		a.NotError(MustParse(src).Check(typ, nil), src)
+	}
+
+	for _, src := range []string{
+		"age >= 18",
+		"private > 1",
+		"Address.NotExists",
+		"Age.City",
+		"[1, NotExists]",
+		"!NotExists",
+		"len(NotExists)",
+		"email(NotExists)",
+		"1 + NotExists",
+	} {
+		a.Error(MustParse(src).Check(typ, nil), src)
+	}
+
+	a.NotError(MustParse("age").Check(typ, func(f reflect.StructField) []string { return []string{strings.ToLower(f.Name)} }))
+	a.NotError(MustParse("NotExists.NotExists").Check(reflect.TypeOf(map[string]any{}), nil))
+	a.Error(MustParse("NotExists").Check(reflect.TypeOf(map[int]any{}), nil))
+	a.NotError(MustParse("NotExists").Check(nil, nil))
+
+	// 同时匹配多个字段
+	type ambiguous struct {
+		Name     string `json:"UserName"`
+		UserName string
+		Nick     string `json:"nick"`
+	}
+	amb := reflect.TypeOf(ambiguous{})
+	a.Error(MustParse("UserName != ''").Check(amb, nil))
+	a.NotError(MustParse("Name != '' && nick != ''").Check(amb, nil))
+	_, err := MustParse("UserName != ''").Eval(ambiguous{Name: "x"})
+	a.Error(err)
+}
+
+func TestExpr_IsValid(t *testing.T) {
+	a := assert.New(t, false)
+
+	var v validator.Validator = MustParse("Age >= 18 || Guardian != ''")
+	a.True(v.IsValid(&object{Age: 18})).
+		True(v.IsValid(&object{Guardian: "g"})).
+		False(v.IsValid(&object{})).
+		False(v.IsValid(5))
+
+	a.False(MustParse("Age").IsValid(&object{Age: 18}))
+}
+
+func TestRegister(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Panic(func() { Register("len", validator.Email) })
+
+	_, err := Parse("even(Age)")
+	a.Error(err)
+
+	Register("even", validator.ValidateFunc(func(v any) bool {
+		f, ok := v.(float64)
+		return ok && int(f)%2 == 0
+	}))
+	defer func() {
+		funcsMux.Lock()
+		delete(funcs, "even")
+		funcsMux.Unlock()
+	}()
+
+	e := MustParse("even(Age)")
+	a.True(e.IsValid(&object{Age: 2})).False(e.IsValid(&object{Age: 1}))
+}
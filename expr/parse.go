@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: MIT
+
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOperator
+)
+
+type (
+	tokenKind int8
+
+	token struct {
+		kind tokenKind
+		val  string
+		pos  int
+	}
+
+	parser struct {
+		tokens []token
+		index  int
+	}
+)
+
+// 二元运算符的优先级，值越大优先级越高。
+var precedences = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3, "<": 3, "<=": 3, ">": 3, ">=": 3, "in": 3,
+	"+": 4, "-": 4,
+	"*": 5, "/": 5, "%": 5,
+}
+
+func parse(src string) (node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.parseBinary(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, &syntaxError{pos: t.pos, msg: fmt.Sprintf("多余的内容 %s", t.val)}
+	}
+	return n, nil
+}
+
+func lex(src string) ([]token, error) {
+	tokens := make([]token, 0, 10)
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(src) {
+				r, size = utf8.DecodeRuneInString(src[i:])
+				if r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				i += size
+			}
+
+			val := src[start:i]
+			if strings.HasSuffix(val, ".") || strings.Contains(val, "..") {
+				return nil, &syntaxError{pos: start, msg: fmt.Sprintf("无效的标识符 %s", val)}
+			}
+			kind := tokenIdent
+			if val == "in" {
+				kind = tokenOperator
+			}
+			tokens = append(tokens, token{kind: kind, val: val, pos: start})
+		case r >= '0' && r <= '9':
+			start := i
+			for i < len(src) && (src[i] == '.' || (src[i] >= '0' && src[i] <= '9')) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, val: src[start:i], pos: start})
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			var buf strings.Builder
+			for {
+				if i >= len(src) {
+					return nil, &syntaxError{pos: start, msg: "字符串缺少结束符"}
+				}
+				c := src[i]
+				if c == byte(r) {
+					i++
+					break
+				}
+				if c == '\\' && i+1 < len(src) {
+					i++
+					c = src[i]
+				}
+				buf.WriteByte(c)
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenString, val: buf.String(), pos: start})
+		default:
+			op := ""
+			for _, o := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ","} {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, &syntaxError{pos: i, msg: fmt.Sprintf("无效的字符 %c", r)}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, val: op, pos: i})
+			i += len(op)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+func (p *parser) peek() token { return p.tokens[p.index] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.index]
+	if t.kind != tokenEOF {
+		p.index++
+	}
+	return t
+}
+
+func (p *parser) expect(op string) error {
+	if t := p.next(); t.kind != tokenOperator || t.val != op {
+		return p.unexpected(t, op)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(t token, want string) error {
+	if t.kind == tokenEOF {
+		return &syntaxError{pos: t.pos, msg: fmt.Sprintf("缺少 %s", want)}
+	}
+	return &syntaxError{pos: t.pos, msg: fmt.Sprintf("需要 %s，但是出现了 %s", want, t.val)}
+}
+
+// 解析优先级不低于 prec 的二元运算
+func (p *parser) parseBinary(prec int) (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		opPrec, found := precedences[t.val]
+		if t.kind != tokenOperator || !found || opPrec < prec {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseBinary(opPrec + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: t.val, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if t := p.peek(); t.kind == tokenOperator && (t.val == "!" || t.val == "-") {
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: t.val, n: n}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		f, err := strconv.ParseFloat(t.val, 64)
+		if err != nil {
+			return nil, &syntaxError{pos: t.pos, msg: fmt.Sprintf("无效的数值 %s", t.val)}
+		}
+		return &literalNode{val: f}, nil
+	case tokenString:
+		return &literalNode{val: t.val}, nil
+	case tokenIdent:
+		switch t.val {
+		case "true":
+			return &literalNode{val: true}, nil
+		case "false":
+			return &literalNode{val: false}, nil
+		case "nil":
+			return &literalNode{val: nil}, nil
+		}
+
+		if p.peek().val == "(" && p.peek().kind == tokenOperator {
+			return p.parseCall(t)
+		}
+		return &identNode{path: strings.Split(t.val, ".")}, nil
+	case tokenOperator:
+		switch t.val {
+		case "(":
+			n, err := p.parseBinary(1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "[":
+			elems, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return &listNode{elems: elems}, nil
+		}
+	}
+
+	return nil, p.unexpected(t, "表达式")
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	p.next() // (
+	args, err := p.parseList(")")
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != 1 {
+		return nil, &syntaxError{pos: name.pos, msg: fmt.Sprintf("函数 %s 只能有一个参数", name.val)}
+	}
+
+	if name.val == "len" {
+		return &lenNode{n: args[0]}, nil
+	}
+
+	v, found := lookupFunc(name.val)
+	if !found {
+		return nil, &syntaxError{pos: name.pos, msg: fmt.Sprintf("不存在的函数 %s", name.val)}
+	}
+	return &callNode{validator: v, n: args[0]}, nil
+}
+
+// 解析以逗号分隔且以 end 结尾的表达式列表
+func (p *parser) parseList(end string) ([]node, error) {
+	nodes := make([]node, 0, 5)
+	if t := p.peek(); t.kind == tokenOperator && t.val == end {
+		p.next()
+		return nodes, nil
+	}
+
+	for {
+		n, err := p.parseBinary(1)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+
+		t := p.next()
+		if t.kind == tokenOperator && t.val == end {
+			return nodes, nil
+		}
+		if t.kind != tokenOperator || t.val != "," {
+			return nil, p.unexpected(t, end)
+		}
+	}
+}
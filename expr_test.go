@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/expr"
+)
+
+func TestValidation_NewExprField(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	type object struct {
+		Age      int
+		Guardian string
+		Items    []int
+		Quota    int
+	}
+
+	guardian := expr.MustParse(`Age >= 18 || Guardian != ""`)
+	quota := expr.MustParse(`len(Items) <= Quota`)
+
+	v := New(ContinueAtError, 10).
+		NewExprField(&object{Age: 15, Items: []int{1}}, "guardian", guardian, "guardian").
+		NewExprField(&object{Age: 18, Items: []int{1}}, "items", quota, "quota")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"guardian": {"guardian"},
+		"items":    {"quota"},
+	})
+
+	v = New(ExitAtError, 10).
+		NewExprField(map[string]any{"Age": 15, "Guardian": ""}, "guardian", guardian, "guardian").
+		NewExprField(map[string]any{}, "items", quota, "quota")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"guardian": {"guardian"},
+	})
+
+	// 计算出错
+	v = New(ContinueAtError, 10).
+		Nested("obj", func(v *Validation) {
+			v.NewExprField(map[string]any{}, "items", quota, "quota")
+		})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"obj.items": {"quota"},
+	})
+	v = New(ContinueAtError, 10).
+		NewExprField(&struct{ Addr *struct{ City string } }{}, "city", expr.MustParse("Addr.City != ''"), "city")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"city": {"city"},
+	})
+
+	// 字段名称
+	type named struct {
+		UserAge  int    `json:"age"`
+		Guardian string `form:"g,omitempty"`
+		NickName string
+		Title    string `label:"caption" validate:"required"`
+	}
+	e := expr.MustParse(`age >= 18 || Guardian != ""`)
+	a.Empty(New(ContinueAtError, 10).NewExprField(&named{Guardian: "g"}, "f", e, "f").Messages())
+	a.NotEmpty(New(ContinueAtError, 10).NewExprField(&named{}, "f", e, "f").Messages())
+
+	e = expr.MustParse(`g != ""`)
+	a.Empty(New(ContinueAtError, 10, WithFieldName("form", nil)).NewExprField(&named{Guardian: "g"}, "f", e, "f").Messages())
+	a.PanicString(func() {
+		New(ContinueAtError, 10).NewExprField(&named{}, "f", e, "f")
+	}, "g")
+	a.PanicString(func() {
+		New(ContinueAtError, 10).NewExprField(&named{}, "f", expr.MustParse(`nick_name != ""`), "f")
+	}, "nick_name")
+
+	e = expr.MustParse(`f_userage >= 18`)
+	v = New(ContinueAtError, 10, WithFieldName("", func(s string) string { return "f_" + strings.ToLower(s) }))
+	a.Empty(v.NewExprField(&named{UserAge: 18}, "f", e, "f").Messages())
+
+	a.PanicString(func() {
+		New(ContinueAtError, 10).NewExprField(&named{}, "f", expr.MustParse("Age >= 18"), "f")
+	}, "Age")
+	a.PanicString(func() {
+		New(ContinueAtError, 10).NewExprField(&named{}, "f", expr.MustParse("caption == ''"), "f")
+	}, "caption")
+
+	// 同一名称匹配多个字段
+	type ambiguous struct {
+		Name  string
+		Title string `json:"Name"`
+	}
+	a.PanicString(func() {
+		New(ContinueAtError, 10).NewExprField(&ambiguous{}, "f", expr.MustParse("Name == ''"), "f")
+	}, "Title")
+}
+
+func TestSchema_Expr(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	type object struct {
+		Age      int    `json:"age"`
+		Guardian string `json:"guardian"`
+	}
+
+	s := NewSchema[object]().
+		Expr("guardian", `Age >= 18 || Guardian != ""`, "guardian")
+	a.Equal(s.Validate(&object{Age: 15}, ContinueAtError).LocaleMessages(p), LocaleMessages{
+		"guardian": {"guardian"},
+	})
+	a.Empty(s.Validate(&object{Age: 15, Guardian: "g"}, ContinueAtError).Messages())
+
+	a.Panic(func() {
+		NewSchema[object]().Expr("guardian", `Age >=`, "guardian")
+	})
+
+	// 在声明时检查字段
+	a.NotPanic(func() {
+		NewSchema[object]().Expr("guardian", `age >= 18 || guardian != ""`, "guardian")
+	})
+	a.PanicString(func() {
+		NewSchema[object]().Expr("guardian", `Age >= 18 || Guardain != ""`, "guardian")
+	}, "Guardain")
+	a.PanicString(func() {
+		NewSchema[object]().Expr("guardian", `Age >= 18 || GUARDIAN != ""`, "guardian")
+	}, "GUARDIAN")
+}
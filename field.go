@@ -0,0 +1,298 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/issue9/sliceutil"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/filter"
+	"github.com/issue9/validation/validator"
+)
+
+// FieldBuilder 以链式调用的方式验证单个字段
+//
+// 由 Validation.Field 返回，每调用一次方法即添加一条规则，
+// 最后需要调用 Validation 方法才会真正执行验证，产生的错误信息与以相同的规则调用 Validation.NewField 是相同的：
+//
+//	v.Field(o.Age, "age").Required("不能为空").Min(18, "不能小于 18").Max(120, "不能大于 120").Validation()
+type FieldBuilder struct {
+	v     *Validation
+	val   any
+	name  string
+	rules []*Rule
+	done  bool // 是否已经执行了验证
+}
+
+// Field 以链式调用的方式验证字段
+//
+// 参数与 NewField 相同，如果需要 Filter 和 Default 写回处理后的值，应该传递字段的指针。
+func (v *Validation) Field(val any, name string) *FieldBuilder {
+	return &FieldBuilder{v: v, val: val, name: name}
+}
+
+// Validation 以之前添加的规则验证字段并返回关联的 Validation 对象
+//
+// 只有调用此方法之后才会执行验证，之后可以继续声明其它字段。
+// 多次调用只会执行一次验证。
+func (f *FieldBuilder) Validation() *Validation {
+	if !f.done {
+		f.done = true
+		f.v.NewField(f.val, f.name, f.rules...)
+	}
+	return f.v
+}
+
+// Rule 添加 rules 作为字段的验证规则
+func (f *FieldBuilder) Rule(rules ...*Rule) *FieldBuilder {
+	f.rules = append(f.rules, rules...)
+	return f
+}
+
+// Check 以自定义的验证器验证字段
+//
+// key 和 args 为验证失败时的错误信息，与 NewRule 的参数相同。
+func (f *FieldBuilder) Check(validator Validator, key message.Reference, args ...any) *FieldBuilder {
+	return f.Rule(NewRule(validator, key, args...))
+}
+
+// Bail 如果之前的规则验证失败，则不再验证之后的规则
+func (f *FieldBuilder) Bail() *FieldBuilder { return f.Rule(&Rule{bail: true}) }
+
+// OmitEmpty 当字段的值为空时跳过之后的所有规则
+func (f *FieldBuilder) OmitEmpty() *FieldBuilder { return f.Rule(OmitEmpty()) }
+
+// OmitNil 当字段的值为 nil 时跳过之后的所有规则
+func (f *FieldBuilder) OmitNil() *FieldBuilder { return f.Rule(OmitNil()) }
+
+// Filter 对字段的值进行过滤
+func (f *FieldBuilder) Filter(filters ...filter.Filter) *FieldBuilder {
+	return f.Rule(Filter(filters...))
+}
+
+// Default 为字段指定默认值
+func (f *FieldBuilder) Default(val any) *FieldBuilder { return f.Rule(Default(val)) }
+
+// Required 字段的值不能为空
+func (f *FieldBuilder) Required(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.Required(false), key, args...)
+}
+
+// RequiredIf 当 other 的值为 values 中的任意一个时，字段的值不能为空
+func (f *FieldBuilder) RequiredIf(other any, values []any, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.RequiredIf(false, other, values...), key, args...)
+}
+
+// RequiredUnless 除非 other 的值为 values 中的任意一个，否则字段的值不能为空
+func (f *FieldBuilder) RequiredUnless(other any, values []any, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.RequiredUnless(false, other, values...), key, args...)
+}
+
+// RequiredWith 当 others 中的任意一个值不为空时，字段的值不能为空
+func (f *FieldBuilder) RequiredWith(others []any, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.RequiredWith(false, others...), key, args...)
+}
+
+// RequiredWithout 当 others 中的任意一个值为空时，字段的值不能为空
+func (f *FieldBuilder) RequiredWithout(others []any, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.RequiredWithout(false, others...), key, args...)
+}
+
+// ExcludedIf 当 other 的值为 values 中的任意一个时，字段的值必须为空
+func (f *FieldBuilder) ExcludedIf(other any, values []any, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.ExcludedIf(other, values...), key, args...)
+}
+
+// Range 字段的值必须在 [min,max] 之间
+func (f *FieldBuilder) Range(min, max float64, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.Range(min, max), key, args...)
+}
+
+// Min 字段的值不能小于 min
+func (f *FieldBuilder) Min(min float64, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.Min(min), key, args...)
+}
+
+// Max 字段的值不能大于 max
+func (f *FieldBuilder) Max(max float64, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.Max(max), key, args...)
+}
+
+// Length 字段的长度必须在 [min,max] 之间
+func (f *FieldBuilder) Length(min, max int64, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.Length(min, max), key, args...)
+}
+
+// MinLength 字段的长度不能小于 min
+func (f *FieldBuilder) MinLength(min int64, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.MinLength(min), key, args...)
+}
+
+// MaxLength 字段的长度不能大于 max
+func (f *FieldBuilder) MaxLength(max int64, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.MaxLength(max), key, args...)
+}
+
+// StringLength 以 unit 计算的字符串长度必须在 [min,max] 之间
+func (f *FieldBuilder) StringLength(unit validator.LengthUnit, min, max int64, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.StringLength(unit, min, max), key, args...)
+}
+
+// In 字段的值必须是 elems 中的任意一个
+func (f *FieldBuilder) In(elems []any, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.ValidateFunc(func(v any) bool { return inElems(elems, v) }), key, args...)
+}
+
+// NotIn 字段的值不能是 elems 中的任意一个
+func (f *FieldBuilder) NotIn(elems []any, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.ValidateFunc(func(v any) bool { return !inElems(elems, v) }), key, args...)
+}
+
+// Contains 字段的值必须包含 elems 中的所有元素
+func (f *FieldBuilder) Contains(elems []any, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.ValidateFunc(func(v any) bool {
+		rv := reflect.ValueOf(v)
+		if kind := rv.Kind(); kind != reflect.Slice && kind != reflect.Array {
+			return false
+		}
+
+		values := make([]any, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			values = append(values, rv.Index(i).Interface())
+		}
+		for _, elem := range elems {
+			if !inElems(values, elem) {
+				return false
+			}
+		}
+		return true
+	}), key, args...)
+}
+
+// Unique 字段中的元素不能重复
+func (f *FieldBuilder) Unique(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.Unique, key, args...)
+}
+
+// Sorted 字段中的元素必须按从小到大的顺序排列
+func (f *FieldBuilder) Sorted(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.Sorted, key, args...)
+}
+
+// NotEmptyCount 字段中非空元素的数量必须在 [min,max] 之间
+func (f *FieldBuilder) NotEmptyCount(min, max int, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.NotEmptyCount(min, max), key, args...)
+}
+
+// AtLeastOne 字段中至少有一个元素不为空
+func (f *FieldBuilder) AtLeastOne(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.AtLeastOne, key, args...)
+}
+
+// ExactlyOne 字段中有且只有一个元素不为空
+func (f *FieldBuilder) ExactlyOne(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.ExactlyOne, key, args...)
+}
+
+// AtMostOne 字段中最多只有一个元素不为空
+func (f *FieldBuilder) AtMostOne(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.AtMostOne, key, args...)
+}
+
+// And 字段的值必须满足 validators 中的所有验证器
+func (f *FieldBuilder) And(validators []Validator, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.And(validators...), key, args...)
+}
+
+// Or 字段的值只要满足 validators 中的任意一个验证器即可
+func (f *FieldBuilder) Or(validators []Validator, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.Or(validators...), key, args...)
+}
+
+// Match 字段的值必须匹配正则表达式 exp
+func (f *FieldBuilder) Match(exp *regexp.Regexp, key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.Match(exp), key, args...)
+}
+
+// GB32100 字段的值必须是符合 GB32100 的统一信用代码
+func (f *FieldBuilder) GB32100(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.GB32100, key, args...)
+}
+
+// GB11643 字段的值必须是符合 GB11643 的身份证号码
+func (f *FieldBuilder) GB11643(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.GB11643, key, args...)
+}
+
+// HexColor 字段的值必须是 16 进制的颜色值
+func (f *FieldBuilder) HexColor(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.HexColor, key, args...)
+}
+
+// BankCard 字段的值必须是银行卡号
+func (f *FieldBuilder) BankCard(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.BankCard, key, args...)
+}
+
+// ISBN 字段的值必须是 ISBN
+func (f *FieldBuilder) ISBN(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.ISBN, key, args...)
+}
+
+// URL 字段的值必须是 URL
+func (f *FieldBuilder) URL(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.URL, key, args...)
+}
+
+// IP 字段的值必须是 IP 地址
+func (f *FieldBuilder) IP(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.IP, key, args...)
+}
+
+// IP4 字段的值必须是 IPv4 地址
+func (f *FieldBuilder) IP4(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.IP4, key, args...)
+}
+
+// IP6 字段的值必须是 IPv6 地址
+func (f *FieldBuilder) IP6(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.IP6, key, args...)
+}
+
+// Email 字段的值必须是 Email
+func (f *FieldBuilder) Email(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.Email, key, args...)
+}
+
+// CNPhone 字段的值必须是中国大陆的电话号码
+func (f *FieldBuilder) CNPhone(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.CNPhone, key, args...)
+}
+
+// CNMobile 字段的值必须是中国大陆的手机号码
+func (f *FieldBuilder) CNMobile(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.CNMobile, key, args...)
+}
+
+// CNTel 字段的值必须是中国大陆的手机号码或电话号码
+func (f *FieldBuilder) CNTel(key message.Reference, args ...any) *FieldBuilder {
+	return f.Check(validator.CNTel, key, args...)
+}
+
+// any 在 go1.20 之前并不满足 comparable 约束，无法直接使用 validator.In 等泛型函数。
+func inElems(elems []any, v any) bool {
+	return sliceutil.Exists(elems, func(elem any) bool { return equal(elem, v) })
+}
+
+// 比较 a 和 b 是否相等，无法以 == 比较的值由 reflect.DeepEqual 进行比较。
+func equal(a, b any) (eq bool) {
+	defer func() {
+		if recover() != nil { // 相同的不可比较类型
+			eq = reflect.DeepEqual(a, b)
+		}
+	}()
+	return a == b
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/filter"
+	"github.com/issue9/validation/validator"
+)
+
+func TestValidation_Field(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	age := 5
+	v := New(ContinueAtError, 10)
+	v.Field(age, "age").Required("required").Min(18, "min").Max(120, "max").Range(10, 20, "range").Validation()
+	v.Field("", "name").Required("required").MinLength(2, "min-length").Validation()
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"age":  {"min", "range"},
+		"name": {"required", "min-length"},
+	})
+
+	// 与 NewField 的结果相同
+	v2 := New(ContinueAtError, 10).
+		NewField(age, "age", NewRule(validator.Required(false), "required"), NewRule(validator.Min(18), "min"), NewRule(validator.Max(120), "max"), NewRule(validator.Range(10, 20), "range")).
+		NewField("", "name", NewRule(validator.Required(false), "required"), NewRule(validator.MinLength(2), "min-length"))
+	a.Equal(v.Messages(), v2.Messages())
+
+	v = New(ExitFieldAtError, 10)
+	v.Field(age, "age").Required("required").Min(18, "min").Range(10, 20, "range").Validation()
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"age": {"min"},
+	})
+
+	v = New(ExitAtError, 10)
+	v.Field(age, "f1").Min(18, "min").Range(10, 20, "range").
+		Validation().
+		Field(age, "f2").Min(18, "min").Validation()
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f1": {"min"},
+	})
+
+	// Nested
+	v = New(ContinueAtError, 10).Nested("obj", func(v *Validation) {
+		v.Field(nil, "f1").Required("required").Email("email").Validation()
+		v.Field(nil, "f2").OmitNil().Required("required").Validation()
+	})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"obj.f1": {"required", "email"},
+	})
+}
+
+func TestFieldBuilder_NewField(t *testing.T) {
+	a := assert.New(t, false)
+
+	required := NewRule(validator.Required(false), "required")
+	fail := NewRule(validator.ValidateFunc(func(any) bool { return false }), "fail")
+	str := ""
+	data := []struct {
+		val   any
+		rules []*Rule
+	}{
+		{val: &str, rules: []*Rule{required}},
+		{val: "x", rules: []*Rule{ParseInt("int"), required}},
+		{val: &address{}},
+		{val: &address{}, rules: []*Rule{required}},
+		{val: &address{}, rules: []*Rule{required, fail, required}},
+		{val: &address{Tags: []string{"1"}}, rules: []*Rule{OmitEmpty(), required}},
+		{val: (*address)(nil), rules: []*Rule{OmitNil(), fail}},
+		{val: &user{Addresses: []*address{{}}}, rules: []*Rule{required}},
+	}
+
+	for _, errHandling := range []ErrorHandling{ContinueAtError, ExitFieldAtError, ExitAtError} {
+		for i, item := range data {
+			v1 := New(errHandling, 10)
+			v1.Field(item.val, "f").Rule(item.rules...).Validation()
+			v2 := New(errHandling, 10).NewField(item.val, "f", item.rules...)
+			a.Equal(v1.Messages(), v2.Messages(), "%d,%d", errHandling, i)
+		}
+	}
+}
+
+func TestFieldBuilder_Bail(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	v := New(ContinueAtError, 10)
+	v.Field("", "f1").Required("required").Bail().Email("email").Validation()
+	v.Field("a", "f2").Required("required").Bail().Email("email").MinLength(2, "min-length").Validation()
+	v.Field("a", "f3").Rule(NewRule(validator.Email, "email").Bail()).MinLength(2, "min-length").Validation()
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f1": {"required"},
+		"f2": {"email", "min-length"},
+		"f3": {"email"},
+	})
+}
+
+func TestFieldBuilder_Check(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	even := validator.ValidateFunc(func(v any) bool { return v.(int)%2 == 0 })
+	n := 3
+	v := New(ContinueAtError, 10)
+	v.Field(n, "n").Check(even, "even").Validation()
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"n": {"even"},
+	})
+}
+
+func TestFieldBuilder_markers(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	name := "  "
+	page := 0
+	var size *int
+	v := New(ContinueAtError, 10)
+	v.Field(&name, "name").Filter(filter.Trim).Required("required").Validation()
+	v.Field(&page, "page").Default(1).Min(1, "min").Validation()
+	v.Field(&size, "size").Default(20).Max(10, "max").Validation()
+	v.Field("", "email").OmitEmpty().Email("email").Validation()
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"name": {"required"},
+		"size": {"max"},
+	})
+	a.Empty(name).Equal(page, 1).Equal(*size, 20)
+}
+
+func TestFieldBuilder_validators(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	v := New(ContinueAtError, 10)
+	v.Field("abc", "in").In([]any{"abc", "def"}, "in").NotIn([]any{"abc"}, "not-in").Validation()
+	v.Field([]int{3, 1, 3}, "slice").Contains([]any{1, 3}, "contains").Contains([]any{2}, "contains").
+		Unique("unique").Sorted("sorted").NotEmptyCount(1, 2, "count").Length(1, 2, "length").Validation()
+	v.Field("中文", "str").StringLength(validator.RuneUnit, 1, 2, "rune").MaxLength(5, "max-length").
+		Match(regexp.MustCompile("^[a-z]+$"), "match").Validation()
+	v.Field("x", "is").GB32100("gb32100").GB11643("gb11643").HexColor("hex").BankCard("bank").ISBN("isbn").
+		URL("url").IP("ip").IP4("ip4").IP6("ip6").CNPhone("phone").CNMobile("mobile").CNTel("tel").Validation()
+	v.Field("", "required").RequiredIf("person", []any{"person"}, "if").RequiredUnless("person", []any{"company"}, "unless").
+		RequiredWith([]any{"v"}, "with").RequiredWithout([]any{""}, "without").Validation()
+	v.Field("v", "excluded").ExcludedIf("person", []any{"person"}, "excluded").Validation()
+	v.Field([]string{"", "1", "2"}, "group").AtLeastOne("at-least").ExactlyOne("exactly").AtMostOne("at-most").Validation()
+	v.Field(5, "and").And([]Validator{validator.Min(1), validator.Max(3)}, "and").
+		Or([]Validator{validator.Max(3), validator.Min(10)}, "or").Validation()
+	v.Field([]int{1}, "uncomparable").In([]any{[]int{1}}, "in").NotIn([]any{[]int{1}}, "not-in").
+		Contains([]any{1}, "contains").Validation()
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"in":       {"not-in"},
+		"slice":    {"contains", "unique", "sorted", "count", "length"},
+		"str":      {"max-length", "match"},
+		"is":       {"gb32100", "gb11643", "hex", "bank", "isbn", "url", "ip", "ip4", "ip6", "phone", "mobile", "tel"},
+		"required": {"if", "unless", "with", "without"},
+		"excluded": {"excluded"},
+		"group":    {"exactly", "at-most"},
+		"and":      {"and", "or"},
+
+		"uncomparable": {"not-in"},
+	})
+}
+
+type fieldCounter struct{ count int }
+
+func (c *fieldCounter) ValidateFields(v *Validation) {
+	c.count++
+	v.NewField("", "name", NewRule(validator.Required(false), "required"))
+}
+
+func TestFieldBuilder_Validation(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	// 只有调用 Validation 才会执行验证，且只执行一次
+	c := &fieldCounter{}
+	v := New(ContinueAtError, 10)
+	f := v.Field(c, "c").Required("required").Check(validator.Required(false), "required").Required("required")
+	a.Equal(c.count, 0).Empty(v.Messages())
+	f.Validation().Field("", "f").Required("required").Validation()
+	f.Validation()
+	a.Equal(c.count, 1).Equal(v.LocaleMessages(p), LocaleMessages{
+		"c.name": {"required"},
+		"f":      {"required"},
+	})
+}
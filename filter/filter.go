@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+
+// Package filter 提供在验证之前对字符串进行过滤和规范化的函数
+package filter
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// 一些常用的过滤器
+var (
+	Trim          = Filter(strings.TrimSpace)   // 去除首尾的空白字符
+	CollapseSpace = Filter(collapseSpace)       // 去除首尾的空白字符，并将中间连续的空白字符合并为一个空格
+	Lower         = Filter(strings.ToLower)     // 转换为小写
+	Upper         = Filter(strings.ToUpper)     // 转换为大写
+	HalfWidth     = Filter(width.Narrow.String) // 将全角字符转换为对应的半角字符，比如 "１２３" 转换为 "123"
+	NFC           = Filter(norm.NFC.String)     // 转换为 Unicode NFC 规范形式
+)
+
+// Filter 对字符串进行过滤
+type Filter func(string) string
+
+// Strip 声明删除字符串中所有在 chars 中出现的字符的过滤器
+//
+// 比如 Strip(" -") 可以将 "138-0013 8000" 转换为 "13800138000"。
+func Strip(chars string) Filter {
+	return func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if strings.ContainsRune(chars, r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+}
+
+// Chain 将多个过滤器按顺序合并为一个过滤器
+func Chain(f ...Filter) Filter {
+	return func(s string) string {
+		for _, ff := range f {
+			s = ff(s)
+		}
+		return s
+	}
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " ")
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: MIT
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+)
+
+func TestFilters(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(Trim(" 13800138000 \t"), "13800138000")
+	a.Equal(CollapseSpace("  a \t b\n\nc  "), "a b c")
+	a.Equal(CollapseSpace("   "), "")
+	a.Equal(Lower("ABC中文"), "abc中文")
+	a.Equal(Upper("abc中文"), "ABC中文")
+	a.Equal(HalfWidth("１２３ａＢ　中文"), "123aB 中文")
+	a.Equal(NFC("e\u0301"), "\u00e9")
+}
+
+func TestStrip(t *testing.T) {
+	a := assert.New(t, false)
+
+	f := Strip(" -")
+	a.Equal(f("+86 138-0013-8000"), "+8613800138000")
+	a.Equal(f(""), "")
+	a.Equal(Strip("")("a b"), "a b")
+}
+
+func TestChain(t *testing.T) {
+	a := assert.New(t, false)
+
+	f := Chain(HalfWidth, Strip(" -+"), Trim)
+	a.Equal(f(" +86 １３８-0013-8000 "), "8613800138000")
+	a.Equal(Chain()("a"), "a")
+}
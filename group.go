@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import "github.com/issue9/sliceutil"
+
+// DefaultGroup 未指定分组的规则所在的分组
+const DefaultGroup = "default"
+
+// WithGroups 指定本次验证需要执行的规则分组
+//
+// 只有属于 groups 中任意一个分组的规则才会被执行，未指定该选项时，只执行 DefaultGroup 中的规则。
+// 如果需要同时执行未分组的规则，需要将 DefaultGroup 也包含在 groups 中：
+//
+//	v := New(ContinueAtError, 10, WithGroups(DefaultGroup, "create"))
+func WithGroups(groups ...string) Option {
+	return func(v *Validation) { v.groups = groups }
+}
+
+// Group 返回属于 groups 分组的规则
+//
+// 返回的是一个新的对象，r 本身并不会被修改，所以同一规则可以在不同的分组中重复使用：
+//
+//	required := NewRule(validator.Required(false), "required")
+//	v.NewField(o.ID, "id", required.Group("update"))
+//
+// 未调用此方法的规则属于 DefaultGroup；
+// OmitEmpty 和 Filter 等用于处理字段值的规则则默认在所有分组中都有效，除非明确指定了分组。
+func (r *Rule) Group(groups ...string) *Rule {
+	rr := *r
+	rr.groups = groups
+	return &rr
+}
+
+// rule 是否属于当前验证的分组
+func (v *Validation) inGroups(rule *Rule) bool {
+	if len(rule.groups) == 0 {
+		if rule.validator == nil {
+			return true
+		}
+		return v.hasGroup(DefaultGroup)
+	}
+
+	return sliceutil.Exists(rule.groups, v.hasGroup)
+}
+
+func (v *Validation) hasGroup(group string) bool {
+	if len(v.groups) == 0 {
+		return group == DefaultGroup
+	}
+	return sliceutil.Exists(v.groups, func(g string) bool { return g == group })
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/validator"
+)
+
+func TestWithGroups(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	type dto struct {
+		ID       int
+		Password string
+		Name     string
+	}
+
+	required := NewRule(validator.Required(false), "required")
+	empty := NewRule(validator.ExcludedIf(true, true), "empty")
+	min6 := NewRule(validator.MinLength(6), "min-6")
+
+	validate := func(o *dto, opt ...Option) LocaleMessages {
+		return New(ContinueAtError, 10, opt...).
+			NewField(o.ID, "id", empty.Group("create"), required.Group("update")).
+			NewField(o.Password, "password", required.Group("create"), OmitEmpty(), min6.Group("create", "update")).
+			NewField(o.Name, "name", required).
+			LocaleMessages(p)
+	}
+
+	a.Equal(validate(&dto{ID: 1, Password: "123"}), LocaleMessages{
+		"name": {"required"},
+	})
+
+	a.Equal(validate(&dto{ID: 1, Password: "123"}, WithGroups("create")), LocaleMessages{
+		"id":       {"empty"},
+		"password": {"min-6"},
+	})
+
+	a.Equal(validate(&dto{ID: 1}, WithGroups(DefaultGroup, "create")), LocaleMessages{
+		"id":       {"empty"},
+		"password": {"required"},
+		"name":     {"required"},
+	})
+
+	a.Equal(validate(&dto{}, WithGroups(DefaultGroup, "update")), LocaleMessages{
+		"id":   {"required"},
+		"name": {"required"},
+	})
+
+	a.Equal(validate(&dto{ID: 1, Password: "123"}, WithGroups("update")), LocaleMessages{
+		"password": {"min-6"},
+	})
+
+	// 原始规则不受影响
+	a.Empty(required.groups)
+
+	// 指定了分组的标记规则
+	v := New(ContinueAtError, 10, WithGroups("update")).
+		NewField("", "f1", OmitEmpty().Group("update"), required.Group("update")).
+		NewField("", "f2", OmitEmpty().Group("create"), required.Group("update"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f2": {"required"},
+	})
+
+	// NewSliceField 类型不匹配
+	v = New(ContinueAtError, 10, WithGroups("update")).
+		NewSliceField(5, "slice", required.Group("create"), min6.Group("update"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"slice": {"min-6"},
+	})
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"github.com/issue9/localeutil"
+	"golang.org/x/text/message"
+)
+
+// LabelTag 结构体中用于指定字段显示名称的标签名称
+//
+// 标签的值会以 localeutil.Phrase 的形式作为字段的显示名称，比如：
+//
+//	type Object struct {
+//	    Mobile string `label:"手机号码" validate:"required"`
+//	}
+const LabelTag = "label"
+
+// Label 在 NewRule 的参数中表示字段的显示名称
+//
+// 在生成错误信息时会被替换为由 Validation.Label 指定的本地化名称，
+// 未指定显示名称的字段，则以字段的完整路径代替：
+//
+//	rule := NewRule(validator.CNMobile, "%s格式不正确", Label)
+//	v.Label("mobile", localeutil.Phrase("手机号码")).
+//	    NewField(o.Mobile, "mobile", rule) // 错误信息为：手机号码格式不正确
+var Label any = fieldLabel{}
+
+type fieldLabel struct{}
+
+// Label 指定字段 name 的显示名称
+//
+// 显示名称仅用于错误信息的内容，错误信息的键名依然是字段的路径。
+// 需要在声明该字段的规则之前调用。
+func (v *Validation) Label(name string, label localeutil.LocaleStringer) *Validation {
+	v.setLabel(v.fieldName(name), label)
+	return v
+}
+
+func (v *Validation) setLabel(path string, label localeutil.LocaleStringer) {
+	if v.labels == nil {
+		v.labels = make(map[string]localeutil.LocaleStringer, 10)
+	}
+	v.labels[path] = label
+}
+
+// 添加字段 name 的错误信息
+//
+// name 为字段的完整路径。
+func (v *Validation) addMessage(name string, rule *Rule) {
+	label, found := v.labels[name]
+	if !found {
+		label = rawLabel(name)
+	}
+	v.messages.Add(name, rule.localeMessage(label))
+}
+
+// 无需翻译的显示名称
+type rawLabel string
+
+func (l rawLabel) LocaleString(*message.Printer) string { return string(l) }
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"github.com/issue9/localeutil"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+
+	"github.com/issue9/validation/validator"
+)
+
+func TestValidation_Label(t *testing.T) {
+	a := assert.New(t, false)
+
+	builder := catalog.NewBuilder()
+	a.NotError(builder.SetString(language.SimplifiedChinese, "mobile", "手机号码"))
+	a.NotError(builder.SetString(language.SimplifiedChinese, "%s format error", "%s格式不正确"))
+	a.NotError(builder.SetString(language.SimplifiedChinese, "%s must be at least %d", "%s不能小于 %d"))
+	a.NotError(builder.SetString(language.AmericanEnglish, "mobile", "mobile number"))
+	cn := message.NewPrinter(language.SimplifiedChinese, message.Catalog(builder))
+	en := message.NewPrinter(language.AmericanEnglish, message.Catalog(builder))
+
+	mobile := NewRule(validator.CNMobile, "%s format error", Label)
+	min18 := NewRule(validator.Min(18), "%s must be at least %d", Label, 18)
+	required := NewRule(validator.Required(false), "required")
+
+	v := New(ContinueAtError, 10).
+		Label("mobile", localeutil.Phrase("mobile")).
+		NewField("123", "mobile", mobile, required).
+		NewField(5, "age", min18).
+		Nested("user", func(v *Validation) {
+			v.Label("mobile", localeutil.Phrase("mobile")).
+				NewField("", "mobile", required, mobile)
+		})
+
+	a.Equal(v.LocaleMessages(cn), LocaleMessages{
+		"mobile":      {"手机号码格式不正确"},
+		"age":         {"age不能小于 18"},
+		"user.mobile": {"required", "手机号码格式不正确"},
+	})
+	a.Equal(v.LocaleMessages(en), LocaleMessages{
+		"mobile":      {"mobile number format error"},
+		"age":         {"age must be at least 18"},
+		"user.mobile": {"required", "mobile number format error"},
+	})
+
+	// 未使用 Label 的规则，错误信息保持不变
+	a.Equal(v.Messages()["user.mobile"][0], localeutil.Phrase("required"))
+
+	// 结构体标签
+	RegisterTagRule("mobile", func(reflect.Value, []string) (*Rule, error) { return mobile, nil })
+	defer delete(tagRules, "mobile")
+	v = New(ContinueAtError, 10).NewStructField(&struct {
+		Mobile string `label:"mobile" validate:"mobile"`
+		Phone  string `validate:"mobile"`
+	}{Mobile: "123", Phone: "123"}, "obj")
+	a.Equal(v.LocaleMessages(cn), LocaleMessages{
+		"obj.Mobile": {"手机号码格式不正确"},
+		"obj.Phone":  {"obj.Phone格式不正确"},
+	})
+}
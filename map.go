@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// 返回按顺序排列的 map 键名
+//
+// 先按类型的 Kind 进行排序，相同 Kind 的数值和字符串等基本类型按其值进行排序，
+// 其它类型则按 fmt.Sprint 的结果进行排序，最后再按类型名称区分值相同但类型不同的键名。
+func sortMapKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool { return lessMapKey(keys[i], keys[j]) })
+	return keys
+}
+
+func lessMapKey(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+
+	if !a.IsValid() || !b.IsValid() { // nil 排在最前
+		return !a.IsValid() && b.IsValid()
+	}
+	if a.Kind() != b.Kind() {
+		return a.Kind() < b.Kind()
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if a.Int() != b.Int() {
+			return a.Int() < b.Int()
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if a.Uint() != b.Uint() {
+			return a.Uint() < b.Uint()
+		}
+	case reflect.Float32, reflect.Float64:
+		if a.Float() != b.Float() {
+			return a.Float() < b.Float()
+		}
+	case reflect.String:
+		if a.String() != b.String() {
+			return a.String() < b.String()
+		}
+	case reflect.Bool:
+		if a.Bool() != b.Bool() {
+			return !a.Bool()
+		}
+	default:
+		if sa, sb := formatMapKey(a), formatMapKey(b); sa != sb {
+			return sa < sb
+		}
+	}
+
+	return a.Type().String() < b.Type().String()
+}
+
+// 将 map 的键名转换为字符串
+func formatMapKey(key reflect.Value) string {
+	if !key.IsValid() {
+		return "<nil>"
+	}
+	return fmt.Sprint(key.Interface())
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+)
+
+func TestSortMapKeys(t *testing.T) {
+	a := assert.New(t, false)
+
+	type myString string
+
+	m := map[any]int{
+		10: 1, 2: 1, "10": 1, "2": 1, myString("2"): 1, 1.5: 1, int8(3): 1,
+		true: 1, false: 1, nil: 1, [1]int{5}: 1, [1]int{1}: 1,
+	}
+	want := []any{nil, false, true, 2, 10, int8(3), 1.5, [1]int{1}, [1]int{5}, "10", "2", myString("2")}
+
+	for i := 0; i < 50; i++ { // map 的遍历顺序是随机的，多次执行以保证结果稳定
+		keys := sortMapKeys(reflect.ValueOf(m))
+		got := make([]any, 0, len(keys))
+		for _, k := range keys {
+			got = append(got, k.Interface())
+		}
+		a.True(reflect.DeepEqual(got, want), "%v", got)
+	}
+}
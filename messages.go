@@ -3,6 +3,9 @@
 package validation
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/issue9/localeutil"
 	"golang.org/x/text/message"
 )
@@ -44,6 +47,76 @@ func (msg MessagesOf[T]) Merge(m MessagesOf[T]) {
 	}
 }
 
+// MergeWithPrefix 将 m 的内容合并到当前实例，m 中的键名都将加上 prefix 作为前缀
+//
+// prefix 会原样添加在键名之前，不会添加任何分隔符，比如子对象的验证结果需要放在 address 之下：
+//
+//	msg.MergeWithPrefix("address.", child)
+func (msg MessagesOf[T]) MergeWithPrefix(prefix string, m MessagesOf[T]) {
+	for key, mm := range m {
+		msg.Add(prefix+key, mm...)
+	}
+}
+
+// Sub 返回键名以 prefix 开头的所有错误信息
+//
+// 返回对象中的键名会去掉 prefix 部分，与 MergeWithPrefix 的作用相反。
+// 返回的是一个新的对象，但是错误信息的内容并不会被复制，
+// 之后再向任意一方添加错误信息，都不会影响另一方。
+func (msg MessagesOf[T]) Sub(prefix string) MessagesOf[T] {
+	sub := make(MessagesOf[T], len(msg))
+	for key, mm := range msg {
+		if strings.HasPrefix(key, prefix) {
+			sub[strings.TrimPrefix(key, prefix)] = mm[:len(mm):len(mm)]
+		}
+	}
+	return sub
+}
+
+// Has 是否存在查询参数 key 的错误信息
+func (msg MessagesOf[T]) Has(key string) bool { return len(msg[key]) > 0 }
+
+// Get 返回查询参数 key 的错误信息
+func (msg MessagesOf[T]) Get(key string) []T { return msg[key] }
+
+// Delete 删除查询参数 key 的错误信息
+func (msg MessagesOf[T]) Delete(key string) { delete(msg, key) }
+
+// Keys 返回按顺序排列的所有键名
+//
+// 与 Has 和 First 相同，不包含错误信息为空的键名。
+func (msg MessagesOf[T]) Keys() []string {
+	keys := make([]string, 0, len(msg))
+	for key, mm := range msg {
+		if len(mm) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// First 返回每个查询参数的第一条错误信息
+//
+// 适用于每个字段只需要显示一条错误信息的场景。
+func (msg MessagesOf[T]) First() map[string]T {
+	first := make(map[string]T, len(msg))
+	for key, mm := range msg {
+		if len(mm) > 0 {
+			first[key] = mm[0]
+		}
+	}
+	return first
+}
+
+// Len 所有错误信息的数量
+func (msg MessagesOf[T]) Len() (c int) {
+	for _, m := range msg {
+		c += len(m)
+	}
+	return c
+}
+
 func Locale(msg Messages, p *message.Printer) LocaleMessages {
 	lm := make(LocaleMessages, len(msg))
 	for k, v := range msg {
@@ -48,3 +48,59 @@ func TestLocaleMessages_Merge(t *testing.T) {
 	a.Equal(m1["key1"], []string{"v1", "v2", "v2", "v3"})
 	a.Equal(m1["key2"], []string{"v1"})
 }
+
+func TestLocaleMessages_Sub(t *testing.T) {
+	a := assert.New(t, false)
+
+	child := LocaleMessages{"city": {"v1"}, "zip": {"v2", "v3"}}
+	m := LocaleMessages{"name": {"v1"}}
+	m.MergeWithPrefix("address.", child)
+	m.MergeWithPrefix("address.", LocaleMessages{"city": {"v4"}})
+	a.Equal(m, LocaleMessages{
+		"name":         {"v1"},
+		"address.city": {"v1", "v4"},
+		"address.zip":  {"v2", "v3"},
+	})
+
+	a.Equal(m.Sub("address."), LocaleMessages{
+		"city": {"v1", "v4"},
+		"zip":  {"v2", "v3"},
+	})
+	a.Empty(m.Sub("not-exists."))
+	a.Equal(m.Sub(""), m)
+
+	// 与原对象互不影响
+	m = make(LocaleMessages, 1)
+	m["a.x"] = make([]string, 1, 4)
+	m["a.x"][0] = "v1"
+	s := m.Sub("a.")
+	s.Add("x", "sub")
+	m.Add("a.x", "parent")
+	a.Equal(s, LocaleMessages{"x": {"v1", "sub"}}).
+		Equal(m, LocaleMessages{"a.x": {"v1", "parent"}})
+}
+
+func TestLocaleMessages_Get(t *testing.T) {
+	a := assert.New(t, false)
+
+	m := LocaleMessages{"k2": {"v1", "v2"}, "k1": {"v3"}, "k3": {}}
+	a.True(m.Has("k1")).
+		False(m.Has("k3")).
+		False(m.Has("not-exists"))
+	a.Equal(m.Get("k2"), []string{"v1", "v2"}).
+		Nil(m.Get("not-exists"))
+
+	a.Equal(m.Keys(), []string{"k1", "k2"})
+	a.Equal(m.First(), map[string]string{"k1": "v3", "k2": "v1"})
+	a.Equal(m.Len(), 3)
+
+	m.Delete("k2")
+	m.Delete("not-exists")
+	a.False(m.Has("k2")).
+		Equal(m.Keys(), []string{"k1"}).
+		Equal(m.Len(), 1)
+
+	a.Empty(LocaleMessages{}.Keys()).
+		Empty(LocaleMessages{}.First()).
+		Equal(LocaleMessages{}.Len(), 0)
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// WithFieldName 指定结构体字段在错误信息中的名称
+//
+// 仅对 NewStructField 有效，处理方式与 encoding/json 相同：
+// tag 表示从该结构体标签中获取字段名称，比如 json、form 和 yaml 等，为空表示不从标签中获取；
+// 标签值为 - 的字段将被忽略，匿名嵌入且未在标签中指定名称的结构体，其字段会被展开到当前结构体中；
+// 标签中未指定名称的字段，则由 naming 对字段名进行转换，naming 为空表示采用原始的字段名，
+// 可以是 SnakeCase、CamelCase 和 KebabCase 等。
+func WithFieldName(tag string, naming func(string) string) Option {
+	return func(v *Validation) {
+		v.nameTag = tag
+		v.naming = naming
+	}
+}
+
+// 返回结构体字段在错误信息中的名称
+//
+// 返回空值表示忽略该字段；
+// flatten 表示该字段为匿名嵌入的结构体，其字段应该展开到当前结构体中。
+func (v *Validation) structFieldName(f reflect.StructField) (name string, flatten bool) {
+	if v.nameTag != "" && f.IsExported() {
+		tag := f.Tag.Get(v.nameTag)
+		if tag == "-" {
+			return "", false
+		}
+		if name, _, _ = strings.Cut(tag, ","); name != "" {
+			return name, false
+		}
+	}
+
+	if f.Anonymous {
+		t := f.Type
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			return "", true
+		}
+	}
+
+	if !f.IsExported() {
+		return "", false
+	}
+
+	if v.naming != nil {
+		return v.naming(f.Name), false
+	}
+	return f.Name, false
+}
+
+// SnakeCase 将字段名转换为 snake_case 格式
+//
+// 比如 UserName 转换为 user_name，IDCard 转换为 id_card。
+func SnakeCase(name string) string { return joinWords(name, "_") }
+
+// KebabCase 将字段名转换为 kebab-case 格式
+//
+// 比如 UserName 转换为 user-name，IDCard 转换为 id-card。
+func KebabCase(name string) string { return joinWords(name, "-") }
+
+// CamelCase 将字段名转换为 camelCase 格式
+//
+// 比如 UserName 转换为 userName，IDCard 转换为 idCard。
+func CamelCase(name string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(w)
+			continue
+		}
+		words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+	}
+	return strings.Join(words, "")
+}
+
+func joinWords(name, sep string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, sep)
+}
+
+// 将驼峰格式的名称拆分成单词，连续的大写字母被当作一个单词，比如 HTTPServer 拆分为 HTTP 和 Server。
+func splitWords(name string) []string {
+	runes := []rune(name)
+	words := make([]string, 0, 3)
+	var start int
+	for i := 1; i < len(runes); i++ {
+		prev, curr := runes[i-1], runes[i]
+		switch {
+		case unicode.IsUpper(curr) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+		case unicode.IsUpper(curr) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+		case curr == '_' || curr == '-':
+		default:
+			continue
+		}
+
+		if start < i {
+			words = append(words, string(runes[start:i]))
+		}
+		start = i
+		if curr == '_' || curr == '-' {
+			start++
+		}
+	}
+
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
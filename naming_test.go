@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+type (
+	Base struct {
+		ID int `json:"id" validate:"required"`
+	}
+
+	base struct {
+		CreatedBy string `validate:"required"`
+	}
+
+	namingObject struct {
+		Base
+		*base
+		UserName string `json:"user_name" validate:"required"`
+		IDCard   string `json:",omitempty" validate:"required"`
+		Password string `json:"-" validate:"required"`
+		Dash     string `json:"-," validate:"required"`
+		Inline   Base   `json:"inline"`
+		Named    Base
+		private  string `form:"private" validate:"required"`
+	}
+)
+
+func TestWithFieldName(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	obj := &namingObject{base: &base{}}
+
+	v := New(ContinueAtError, 10).NewStructField(obj, "obj")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"obj.ID":        {"required"},
+		"obj.CreatedBy": {"required"},
+		"obj.UserName":  {"required"},
+		"obj.IDCard":    {"required"},
+		"obj.Password":  {"required"},
+		"obj.Dash":      {"required"},
+		"obj.Inline.ID": {"required"},
+		"obj.Named.ID":  {"required"},
+	})
+
+	v = New(ContinueAtError, 10, WithFieldName("json", nil)).NewStructField(obj, "obj")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"obj.id":        {"required"},
+		"obj.CreatedBy": {"required"},
+		"obj.user_name": {"required"},
+		"obj.IDCard":    {"required"},
+		"obj.-":         {"required"},
+		"obj.inline.id": {"required"},
+		"obj.Named.id":  {"required"},
+	})
+
+	v = New(ContinueAtError, 10, WithFieldName("json", SnakeCase)).NewStructField(obj, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"id":         {"required"},
+		"created_by": {"required"},
+		"user_name":  {"required"},
+		"id_card":    {"required"},
+		"-":          {"required"},
+		"inline.id":  {"required"},
+		"named.id":   {"required"},
+	})
+
+	v = New(ContinueAtError, 10, WithFieldName("", KebabCase), WithPath(JSONPointer)).NewStructField(obj, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"/id":         {"required"},
+		"/created-by": {"required"},
+		"/user-name":  {"required"},
+		"/id-card":    {"required"},
+		"/password":   {"required"},
+		"/dash":       {"required"},
+		"/inline/id":  {"required"},
+		"/named/id":   {"required"},
+	})
+
+	// 未导出的字段即使指定了名称也会被忽略
+	v = New(ContinueAtError, 10, WithFieldName("form", nil)).NewStructField(obj, "")
+	a.NotContains(v.Messages(), "private").Length(v.Messages(), 8)
+
+	// nil 的嵌入对象
+	obj = &namingObject{Base: Base{ID: 1}, UserName: "1", IDCard: "1", Password: "1", Dash: "1", Inline: Base{ID: 1}, Named: Base{ID: 1}}
+	v = New(ContinueAtError, 10).NewStructField(obj, "obj")
+	a.Empty(v.Messages())
+}
+
+func TestNaming(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []struct {
+		name, snake, kebab, camel string
+	}{
+		{name: "", snake: "", kebab: "", camel: ""},
+		{name: "ID", snake: "id", kebab: "id", camel: "id"},
+		{name: "UserName", snake: "user_name", kebab: "user-name", camel: "userName"},
+		{name: "IDCard", snake: "id_card", kebab: "id-card", camel: "idCard"},
+		{name: "UserID", snake: "user_id", kebab: "user-id", camel: "userId"},
+		{name: "HTTPServer2", snake: "http_server2", kebab: "http-server2", camel: "httpServer2"},
+		{name: "Version2Name", snake: "version2_name", kebab: "version2-name", camel: "version2Name"},
+		{name: "user_name", snake: "user_name", kebab: "user-name", camel: "userName"},
+		{name: "名称", snake: "名称", kebab: "名称", camel: "名称"},
+	}
+
+	for _, item := range data {
+		a.Equal(SnakeCase(item.name), item.snake, "snake %s", item.name).
+			Equal(KebabCase(item.name), item.kebab, "kebab %s", item.name).
+			Equal(CamelCase(item.name), item.camel, "camel %s", item.name)
+	}
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"strconv"
+	"strings"
+)
+
+// 几种常用的路径格式
+var (
+	DotPath     Path = dotPath{}     // 以点号分隔字段，比如 a.b[0].c 和 a[key]
+	SlashPath   Path = slashPath{}   // 以斜杠分隔字段，比如 a/b/0/c 和 a/key
+	JSONPointer Path = jsonPointer{} // RFC 6901 定义的 JSON Pointer，比如 /a/b/0/c
+)
+
+// Path 用于生成字段在错误信息中的完整路径
+//
+// parent 表示父路径，为空表示当前处于顶层。
+type Path interface {
+	// Field 返回子字段 name 的路径
+	Field(parent, name string) string
+
+	// Index 返回数组中第 index 个元素的路径
+	Index(parent string, index int) string
+
+	// Key 返回 map 中键名为 key 的元素路径
+	Key(parent, key string) string
+
+	// KeyName 返回 map 中键名 key 本身的路径
+	//
+	// 用于验证键名时的错误信息，无法与 Key 区分的格式可以返回与 Key 相同的值。
+	KeyName(parent, key string) string
+}
+
+type (
+	dotPath     struct{}
+	slashPath   struct{}
+	jsonPointer struct{}
+)
+
+func (dotPath) Field(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func (dotPath) Index(parent string, index int) string {
+	return parent + "[" + strconv.Itoa(index) + "]"
+}
+
+func (dotPath) Key(parent, key string) string { return parent + "[" + key + "]" }
+
+func (p dotPath) KeyName(parent, key string) string { return p.Key(parent, key) + "#" }
+
+func (slashPath) Field(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
+func (p slashPath) Index(parent string, index int) string {
+	return p.Field(parent, strconv.Itoa(index))
+}
+
+func (p slashPath) Key(parent, key string) string { return p.Field(parent, key) }
+
+// 键名未作转义，任何后缀都可能与其它的键名相同，所以直接使用元素的路径。
+func (p slashPath) KeyName(parent, key string) string { return p.Key(parent, key) }
+
+var jsonPointerReplacer = strings.NewReplacer("~", "~0", "/", "~1")
+
+func (jsonPointer) Field(parent, name string) string {
+	return parent + "/" + jsonPointerReplacer.Replace(name)
+}
+
+func (p jsonPointer) Index(parent string, index int) string {
+	return p.Field(parent, strconv.Itoa(index))
+}
+
+func (p jsonPointer) Key(parent, key string) string { return p.Field(parent, key) }
+
+// JSON Pointer 无法指向键名本身，所以使用元素的路径。
+func (p jsonPointer) KeyName(parent, key string) string { return p.Key(parent, key) }
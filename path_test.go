@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+)
+
+func TestPath(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(DotPath.Field("", "a"), "a")
+	a.Equal(DotPath.Field("a", "b"), "a.b")
+	a.Equal(DotPath.Index("a", 0), "a[0]")
+	a.Equal(DotPath.Field(DotPath.Index("a.b", 0), "c"), "a.b[0].c")
+	a.Equal(DotPath.Key("a", "k"), "a[k]")
+	a.Equal(DotPath.KeyName("a", "k"), "a[k]#")
+
+	a.Equal(SlashPath.Field("", "a"), "a")
+	a.Equal(SlashPath.Field("a", "b"), "a/b")
+	a.Equal(SlashPath.Field(SlashPath.Index("a/b", 0), "c"), "a/b/0/c")
+	a.Equal(SlashPath.Key("a", "k"), "a/k")
+	a.Equal(SlashPath.KeyName("a", "k#"), "a/k#")
+
+	a.Equal(JSONPointer.Field("", "a"), "/a")
+	a.Equal(JSONPointer.Field("/a", "b"), "/a/b")
+	a.Equal(JSONPointer.Field(JSONPointer.Index("/a/b", 0), "c"), "/a/b/0/c")
+	a.Equal(JSONPointer.Key("/a", "k/~"), "/a/k~1~0")
+	a.Equal(JSONPointer.KeyName("/a", "k/~"), "/a/k~1~0")
+	a.Equal(JSONPointer.Field("", ""), "/")
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+// WithPresent 指定实际存在的字段
+//
+// 用于只验证部分字段的场景，比如 PATCH 请求，未包含在 paths 中的字段，
+// 其所有规则（包括 validator.Required）都将被忽略，但是 NewGroupField 等跨字段的约束依然有效。
+//
+// paths 为字段的完整路径，其格式需要与 WithPath 指定的格式相同，
+// 子字段需要单独指定，父字段存在并不代表其子字段也存在；
+// 反之，父字段不存在时，依然会检测其子字段是否存在，只有父字段本身的规则会被忽略。
+// NewSliceField 和 NewMapField 等方法中的元素是个例外，集合本身存在时，其元素也被当作是存在的。
+func WithPresent(paths ...string) Option {
+	return func(v *Validation) {
+		if v.present == nil {
+			v.present = make(map[string]struct{}, len(paths))
+		}
+		for _, p := range paths {
+			v.present[p] = struct{}{}
+		}
+	}
+}
+
+// WithPresentMap 从解码后的数据中获取实际存在的字段
+//
+// doc 一般为从 JSON 等数据中解码而来的 map[string]any，
+// 会遍历其中所有的键名（包括嵌套的 map[string]any 和 []any）并生成字段路径，
+// 其它与 WithPresent 相同。
+func WithPresentMap(doc map[string]any) Option {
+	return func(v *Validation) { v.presentDoc = doc }
+}
+
+func (v *Validation) presentPaths(parent string, val any) {
+	switch vv := val.(type) {
+	case map[string]any:
+		for key, item := range vv {
+			p := v.path.Field(parent, key)
+			v.present[p] = struct{}{}
+			v.presentPaths(p, item)
+		}
+	case []any:
+		for i, item := range vv {
+			p := v.path.Index(parent, i)
+			v.present[p] = struct{}{}
+			v.presentPaths(p, item)
+		}
+	}
+}
+
+// 字段 name 是否不存在
+//
+// name 为字段的完整路径，未指定 WithPresent 或 WithPresentMap 时，所有字段都是存在的。
+func (v *Validation) absent(name string) bool {
+	if v.present == nil {
+		return false
+	}
+	_, found := v.present[name]
+	return !found
+}
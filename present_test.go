@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/validator"
+)
+
+func TestWithPresent(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	required := NewRule(validator.Required(false), "required")
+	atMostOne := NewRule(validator.AtMostOne, "at-most-one")
+
+	v := New(ContinueAtError, 10, WithPresent("name", "tags")).
+		NewField("", "name", required).
+		NewField("", "email", required).
+		NewSliceField([]string{""}, "tags", required).
+		NewSliceField([]string{""}, "tags2", required).
+		NewMapField(map[string]string{"k": ""}, "map", required).
+		NewGroupField(map[string]any{"coupon": "1", "discount": 1}, "group", atMostOne)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"name":    {"required"},
+		"tags[0]": {"required"},
+		"group":   {"at-most-one"},
+	})
+
+	// 集合本身不存在时，分别判断每个元素是否存在
+	v = New(ContinueAtError, 10, WithPresent("tags[1]", "coll[0]", "map[k2]", "keys[k1]#")).
+		NewSliceField([]string{"", ""}, "tags", required).
+		NewSliceField(5, "tags2", required).
+		NewCollectionField([]string{""}, "coll", nil, []*Rule{NewRule(validator.MinLength(2), "min")}, required).
+		NewMapField(map[string]string{"k1": "", "k2": ""}, "map", required).
+		NewMapKeyField(map[string]string{"k1": "", "k2": ""}, "keys", NewRule(validator.MinLength(3), "min"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"tags[1]":   {"required"},
+		"coll[0]":   {"required"},
+		"map[k2]":   {"required"},
+		"keys[k1]#": {"min"},
+	})
+}
+
+func TestWithPresent_descendant(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	type address struct {
+		City string `validate:"required"`
+		Zip  string `validate:"required"`
+	}
+
+	type object struct {
+		Name     string   `validate:"required"`
+		Address  address  `validate:"required"`
+		PAddress *address `validate:"required"`
+		Users    []*user
+	}
+
+	obj := &object{PAddress: &address{}, Users: []*user{{}}}
+
+	// 只指定了子字段，父字段的规则被忽略，但是子字段依然会被验证。
+	v := New(ContinueAtError, 10, WithPresent("Address.City", "PAddress.Zip", "Users[0].name")).NewStructField(obj, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"Address.City":  {"required"},
+		"PAddress.Zip":  {"required"},
+		"Users[0].name": {"required"},
+	})
+
+	// FieldsValidator，与 Nested 的结果相同。
+	v = New(ContinueAtError, 10, WithPresent("user.name")).
+		NewField(&user{}, "user", NewRule(validator.Required(false), "required"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"user.name": {"required"},
+	})
+	v = New(ContinueAtError, 10, WithPresent("user.name")).
+		Nested("user", func(v *Validation) {
+			v.NewField("", "name", NewRule(validator.Required(false), "required"))
+		})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"user.name": {"required"},
+	})
+}
+
+func TestWithPresentMap(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	type address struct {
+		City   string `json:"city" validate:"required"`
+		Street string `json:"street" validate:"required"`
+	}
+
+	type object struct {
+		Name      string     `json:"name" validate:"required"`
+		Email     string     `json:"email" validate:"required"`
+		Address   address    `json:"address"`
+		Addresses []*address `json:"addresses"`
+	}
+
+	patch := map[string]any{}
+	a.NotError(json.Unmarshal([]byte(`{"name":"","address":{"city":""},"addresses":[{"street":""}]}`), &patch))
+
+	// 合并之后的对象
+	obj := &object{Addresses: []*address{{}}}
+
+	v := New(ContinueAtError, 10, WithPresentMap(patch), WithFieldName("json", nil)).NewStructField(obj, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"name":                {"required"},
+		"address.city":        {"required"},
+		"addresses[0].street": {"required"},
+	})
+
+	v = New(ContinueAtError, 10, WithPresentMap(patch), WithFieldName("json", nil), WithPath(JSONPointer), WithPresent("/email")).
+		NewStructField(obj, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"/name":               {"required"},
+		"/email":              {"required"},
+		"/address/city":       {"required"},
+		"/addresses/0/street": {"required"},
+	})
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"reflect"
+
+	"github.com/issue9/localeutil"
+)
+
+type (
+	visitKey struct {
+		ptr uintptr
+		typ reflect.Type
+	}
+
+	// 包装了其它对象的 FieldsValidator
+	//
+	// 在判断循环引用时，以 unwrap 返回的对象代替其本身。
+	wrapper interface {
+		unwrap() any
+	}
+)
+
+// WithMaxDepth 指定自动验证子字段时的最大嵌套层数
+//
+// 在验证结构体、FieldsValidator 以及元素为结构体的数组和 map 时，会自动验证其子字段，
+// depth 表示最多可以嵌套的对象层数，超出之后不再验证，而是以 msg 作为该字段的错误信息；
+// 由 NewField 或 NewStructField 直接指定的对象为第 1 层，数组和 map 本身并不计算层数。
+// depth 小于等于 0 表示不限制，这也是默认值；msg 为 nil 时采用 localeutil.Phrase("exceeds max depth")。
+//
+// 无论是否指定了该选项，已经处于当前验证路径上的指针、切片和 map 都不会被再次验证，
+// 以避免循环引用导致的无限递归，由 Schema.Bind 返回的对象以其绑定的对象作为判断依据。
+func WithMaxDepth(depth int, msg localeutil.LocaleStringer) Option {
+	if msg == nil {
+		msg = localeutil.Phrase("exceeds max depth")
+	}
+
+	return func(v *Validation) {
+		v.maxDepth = depth
+		v.depthMessage = msg
+	}
+}
+
+// 在子路径 name 之下执行 f 以验证 rv 的子字段
+//
+// 如果 rv 已经处于当前的验证路径之上，表示存在循环引用，将直接忽略；
+// 如果超出了 WithMaxDepth 指定的层数，则以其指定的信息作为 name 的错误信息。
+func (v *Validation) recurse(rv reflect.Value, name string, f func(v *Validation)) {
+	if v.maxDepth > 0 && v.depth >= v.maxDepth {
+		v.messages.Add(name, v.depthMessage)
+		return
+	}
+
+	if rv.IsValid() && rv.CanInterface() {
+		if w, ok := rv.Interface().(wrapper); ok {
+			rv = reflect.ValueOf(w.unwrap())
+		}
+	}
+
+	leave, ok := v.visit(rv)
+	if !ok {
+		return
+	}
+	defer leave()
+
+	v.depth++
+	defer func() { v.depth-- }()
+	v.descend(name, f)
+}
+
+// 将 rv 标记为处于当前的验证路径之上
+//
+// 只有非 nil 的指针、切片和 map 才会被标记，返回的 leave 用于取消标记；
+// 如果 rv 已经处于当前的验证路径之上，则返回 false。
+func (v *Validation) visit(rv reflect.Value) (leave func(), ok bool) {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if rv.Pointer() == 0 {
+			return func() {}, true
+		}
+	default:
+		return func() {}, true
+	}
+
+	key := visitKey{ptr: rv.Pointer(), typ: rv.Type()}
+	if _, found := v.visited[key]; found {
+		return nil, false
+	}
+
+	if v.visited == nil {
+		v.visited = make(map[visitKey]struct{}, 5)
+	}
+	v.visited[key] = struct{}{}
+	return func() { delete(v.visited, key) }, true
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"github.com/issue9/localeutil"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/validator"
+)
+
+type (
+	treeNode struct {
+		Name     string `validate:"required"`
+		Parent   *treeNode
+		Children []*treeNode
+	}
+
+	listNode struct {
+		Value int
+		Next  *listNode
+	}
+)
+
+func (n *listNode) ValidateFields(v *Validation) {
+	v.NewField(n.Value, "value", NewRule(validator.Min(1), "min")).
+		NewField(n.Next, "next")
+}
+
+func TestValidation_recurse(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	// 结构体标签
+	root := &treeNode{Name: "root"}
+	child := &treeNode{Parent: root}
+	root.Children = []*treeNode{child, {Name: "c2", Parent: root}}
+	child.Children = []*treeNode{{Parent: child}}
+	v := New(ContinueAtError, 10).NewStructField(root, "root")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"root.Children[0].Name":             {"required"},
+		"root.Children[0].Children[0].Name": {"required"},
+	})
+
+	// 同一对象出现在不同的路径上，并不是循环引用
+	shared := &treeNode{}
+	v = New(ContinueAtError, 10).NewStructField(&struct {
+		A *treeNode
+		B *treeNode
+	}{A: shared, B: shared}, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"A.Name": {"required"},
+		"B.Name": {"required"},
+	})
+
+	// 包含自身的 map 和切片
+	doc := map[string]any{"name": "doc"}
+	list := []any{&treeNode{}, nil}
+	list[1] = list
+	doc["self"] = doc
+	doc["list"] = list
+	v = New(ContinueAtError, 10).NewStructField(&struct{ Doc map[string]any }{Doc: doc}, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"Doc[list][0].Name": {"required"},
+	})
+
+	v = New(ContinueAtError, 10, WithMaxDepth(5, localeutil.Phrase("too deep"))).
+		NewStructField(&struct{ List []any }{List: list}, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"List[0].Name": {"required"},
+	})
+
+	// FieldsValidator
+	head := &listNode{Value: 1}
+	head.Next = &listNode{Value: 0, Next: head}
+	v = New(ContinueAtError, 10).NewField(head, "list")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"list.next.value": {"min"},
+	})
+}
+
+func TestSchema_Bind_cycle(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	var s *Schema[treeNode]
+	s = NewSchema[treeNode]().
+		Field("name", func(n *treeNode) any { return n.Name }, NewRule(validator.Required(false), "required")).
+		Field("parent", func(n *treeNode) any { return s.Bind(n.Parent) }).
+		Check(func(v *Validation, n *treeNode) {
+			for i, c := range n.Children {
+				v.NewField(s.Bind(c), v.path.Index("children", i))
+			}
+		})
+
+	root := &treeNode{Name: "root"}
+	root.Children = []*treeNode{{Parent: root}}
+	root.Parent = root.Children[0]
+	v := s.Validate(root, ContinueAtError)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"parent.name":      {"required"},
+		"children[0].name": {"required"},
+	})
+}
+
+func TestWithMaxDepth(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	depth := localeutil.Phrase("too deep")
+
+	head := &listNode{Value: 1, Next: &listNode{Value: 2, Next: &listNode{Value: 0}}}
+	v := New(ContinueAtError, 10, WithMaxDepth(2, depth)).NewField(head, "list")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"list.next.next": {"too deep"},
+	})
+
+	v = New(ContinueAtError, 10, WithMaxDepth(3, depth)).NewField(head, "list")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"list.next.next.value": {"min"},
+	})
+
+	v = New(ContinueAtError, 10, WithMaxDepth(0, depth)).NewField(head, "list")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"list.next.next.value": {"min"},
+	})
+
+	// 结构体标签
+	root := &treeNode{Name: "root", Children: []*treeNode{{Name: "1", Children: []*treeNode{{}}}}}
+	v = New(ContinueAtError, 10, WithMaxDepth(2, depth)).NewStructField(root, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"Children[0].Children[0]": {"too deep"},
+	})
+
+	v = New(ContinueAtError, 10, WithMaxDepth(1, depth)).NewStructField(root, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"Children[0]": {"too deep"},
+	})
+
+	// 深度计数在验证完成之后恢复
+	v = New(ContinueAtError, 10, WithMaxDepth(2, depth)).
+		NewField(&listNode{Value: 1, Next: &listNode{Value: 1}}, "l1").
+		NewField(&listNode{Value: 1, Next: &listNode{Value: 0}}, "l2")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"l2.next.value": {"min"},
+	})
+	a.Equal(v.depth, 0).Empty(v.visited)
+
+	// 未指定错误信息
+	v = New(ContinueAtError, 10, WithMaxDepth(2, nil)).NewField(head, "list")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"list.next.next": {"exceeds max depth"},
+	})
+}
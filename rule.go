@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/issue9/localeutil"
+	"github.com/issue9/sliceutil"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/filter"
+	"github.com/issue9/validation/is"
+)
+
+// Rule 验证规则
+//
+// 这是对 Validator 的二次包装，保存着未本地化的错误信息，用以在验证失败之后返回给 Validation。
+type Rule struct {
+	validator Validator
+	message   localeutil.LocaleStringer
+
+	// 参数中包含 Label 时，需要在生成错误信息时替换为字段的名称。
+	key   message.Reference
+	args  []any
+	label bool
+
+	// validator 为空时，表示这是一个用于处理字段值的规则，以下两者必定有一个不为空；
+	// 如果两者都为空，则表示这是由 FieldBuilder.Bail 添加的规则，之前的规则验证失败时不再验证之后的规则。
+
+	// 当其返回 true 时，跳过当前字段之后的所有规则。
+	skip func(any) bool
+
+	// 对字段值进行转换，转换后的值将传递给之后的规则，
+	// 如果转换失败，则以 message 作为错误信息，并跳过当前字段之后的所有规则。
+	transform func(any) (any, bool)
+
+	// 是否需要以指针的形式接收字段的值，以便写回转换后的值。
+	ptr bool
+
+	bail bool
+
+	groups []string
+}
+
+// NewRule 声明新的验证规则
+//
+// key 和 v 为验证失败时的错误信息，与 localeutil.Phrase 的参数相同，
+// 其中 v 可以包含 Label，表示在该位置插入字段的显示名称。
+func NewRule(validator Validator, key message.Reference, v ...any) *Rule {
+	return &Rule{
+		validator: validator,
+		message:   localeutil.Phrase(key, v...),
+		key:       key,
+		args:      v,
+		label:     sliceutil.Exists(v, func(arg any) bool { return arg == Label }),
+	}
+}
+
+// Bail 返回验证失败之后中断当前字段验证的规则
+//
+// 无论 ErrorHandling 是什么值，只要该规则验证失败，就不再验证该字段之后的规则，
+// 一般用于后续规则依赖于该规则的情况，比如格式正确之后才需要查询数据库。
+// 返回的是一个新的对象，r 本身并不会被修改。
+func (r *Rule) Bail() *Rule {
+	rr := *r
+	rr.bail = true
+	return &rr
+}
+
+// 返回字段显示名称为 label 时的错误信息
+func (r *Rule) localeMessage(label localeutil.LocaleStringer) localeutil.LocaleStringer {
+	if !r.label {
+		return r.message
+	}
+
+	args := make([]any, 0, len(r.args))
+	for _, arg := range r.args {
+		if arg == Label {
+			arg = label
+		}
+		args = append(args, arg)
+	}
+	return localeutil.Phrase(r.key, args...)
+}
+
+// OmitEmpty 当字段的值为空时跳过该字段之后的所有规则
+//
+// 用于声明可选的字段，比如一个可以为空的 Email 字段：
+//
+//	v.NewField(o.Email, "email", OmitEmpty(), NewRule(validator.Email, "email"))
+//
+// 是否为空的判断规则可参考 github.com/issue9/validation/is.Empty，指针会判断其指向的值。
+// 只对位于其之后的规则有效。
+//
+// 与 validator.Required(true) 不同，OmitEmpty 不会产生任何错误信息。
+func OmitEmpty() *Rule {
+	return &Rule{skip: func(v any) bool { return is.Empty(v, true) }}
+}
+
+// OmitNil 当字段的值为 nil 时跳过该字段之后的所有规则
+//
+// 与 OmitEmpty 不同，指向空值的指针并不会被跳过，适用于指针类型的字段。
+// 是否为 nil 的判断规则可参考 github.com/issue9/validation/is.Nil。
+func OmitNil() *Rule {
+	return &Rule{skip: is.Nil}
+}
+
+// Filter 返回对字段值进行过滤的规则
+//
+// 位于其之后的规则将接收过滤后的值，比如：
+//
+//	v.NewField(&o.Mobile, "mobile", Filter(filter.HalfWidth, filter.Strip(" -")), NewRule(validator.CNMobile, "mobile"))
+//
+// 只能处理 string、[]byte、[]rune 和 *string 类型，其它类型的值保持不变；
+// 如果值为 *string，过滤后的值还会写回该指针，之后的规则接收的是 string 类型的值。
+func Filter(f ...filter.Filter) *Rule {
+	ff := filter.Chain(f...)
+	return &Rule{ptr: true, transform: func(v any) (any, bool) {
+		switch vv := v.(type) {
+		case string:
+			return ff(vv), true
+		case []byte:
+			return []byte(ff(string(vv))), true
+		case []rune:
+			return []rune(ff(string(vv))), true
+		case *string:
+			if vv == nil {
+				return vv, true
+			}
+			*vv = ff(*vv)
+			return *vv, true
+		default:
+			return v, true
+		}
+	}}
+}
+
+// Default 返回为字段指定默认值的规则
+//
+// 当字段的值为零值时，以 val 代替字段的值传递给之后的规则，零值的判断规则可参考 is.Zero；
+// 如果字段是以指针的形式传递的，还会将 val 写回该指针，之后的规则接收的是指针指向的值：
+//
+//	v.NewField(&o.Page, "page", Default(1), NewRule(validator.Min(1), "min-1"))
+//
+// 如果指针指向的是一个 nil 指针，会为其分配内存再写入 val。
+// val 的类型必须能赋值给字段的类型，或是能在数值类型之间相互转换，
+// 数值转换为字符串之类的转换会被拒绝，此时会 panic。
+func Default(val any) *Rule {
+	return &Rule{ptr: true, transform: func(v any) (any, bool) {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			if is.Zero(v, false) {
+				return val, true
+			}
+			return v, true
+		}
+
+		elem := rv.Elem()
+		if !is.Zero(elem.Interface(), true) {
+			return elem.Interface(), true
+		}
+
+		target := elem
+		if elem.Kind() == reflect.Ptr { // 指向 nil 指针
+			target = reflect.New(elem.Type().Elem()).Elem()
+		}
+		target.Set(defaultValue(val, target.Type()))
+		if elem.Kind() == reflect.Ptr {
+			elem.Set(target.Addr())
+		}
+		return elem.Interface(), true
+	}}
+}
+
+// 将默认值 val 转换为类型 t
+func defaultValue(val any, t reflect.Type) reflect.Value {
+	rv := reflect.ValueOf(val)
+	switch {
+	case !rv.IsValid():
+		panic(fmt.Sprintf("默认值不能为 nil，字段类型为 %s", t))
+	case rv.Type().AssignableTo(t):
+		return rv
+	case rv.Type().ConvertibleTo(t) && (t.Kind() != reflect.String || rv.Kind() == reflect.String):
+		return rv.Convert(t)
+	default:
+		panic(fmt.Sprintf("默认值 %v 的类型 %T 无法用于类型为 %s 的字段", val, val, t))
+	}
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/filter"
+	"github.com/issue9/validation/is"
+	"github.com/issue9/validation/validator"
+)
+
+func TestRule_Bail(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	required := NewRule(validator.Required(false), "required")
+	email := NewRule(validator.Email, "email")
+	min := NewRule(validator.MinLength(10), "min-length")
+
+	bail := required.Bail()
+	a.True(bail.bail).False(required.bail)
+
+	v := New(ContinueAtError, 10).
+		NewField("", "f1", required, email, min).
+		NewField("", "f2", bail, email, min).
+		NewField("abc", "f3", bail, email, min).
+		NewField("abc", "f4", email.Bail(), min)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f1": {"required", "email", "min-length"},
+		"f2": {"required"},
+		"f3": {"email", "min-length"},
+		"f4": {"email"},
+	})
+
+	// NewGroupField
+	v = New(ContinueAtError, 10).
+		NewGroupField(map[string]any{"a": "", "b": ""}, "group", NewRule(validator.AtLeastOne, "at-least-one").Bail(), NewRule(validator.ExactlyOne, "exactly-one"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"group": {"at-least-one"},
+	})
+}
+
+func TestOmitEmpty(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	email := NewRule(validator.Email, "email")
+	required := NewRule(validator.Required(false), "required")
+
+	v := New(ContinueAtError, 10).
+		NewField("", "f1", OmitEmpty(), email).
+		NewField("", "f2", email).
+		NewField("invalid", "f3", OmitEmpty(), email).
+		NewField("", "f4", required, OmitEmpty(), email)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f2": {"email"},
+		"f3": {"email"},
+		"f4": {"required"},
+	})
+
+	// 指针
+	var nilStr *string
+	empty := ""
+	v = New(ContinueAtError, 10).
+		NewField(nilStr, "f1", OmitEmpty(), email).
+		NewField(&empty, "f2", OmitEmpty(), email)
+	a.Empty(v.Messages())
+
+	// slice
+	v = New(ContinueAtError, 10).
+		NewSliceField([]string{"", "invalid"}, "slice", OmitEmpty(), email)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"slice[1]": {"email"},
+	})
+
+	// 类型不匹配时，标记规则不会产生错误信息
+	v = New(ExitAtError, 10).
+		NewSliceField(5, "slice", OmitEmpty(), email)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"slice": {"email"},
+	})
+}
+
+func TestOmitNil(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	required := NewRule(validator.Required(false), "required")
+
+	var nilStr *string
+	empty := ""
+	v := New(ContinueAtError, 10).
+		NewField(nilStr, "f1", OmitNil(), required).
+		NewField(&empty, "f2", OmitNil(), required).
+		NewField("", "f3", OmitNil(), required)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f3": {"required"},
+	})
+}
+
+func TestFilter(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	mobile := NewRule(validator.CNMobile, "mobile")
+	number := NewRule(validator.ValidateFunc(is.Number), "number")
+
+	type object struct {
+		Mobile string
+		Number string
+	}
+
+	o := &object{Mobile: " +86 １３８-0013-8000 ", Number: "１２３"}
+	v := New(ContinueAtError, 10).
+		NewField(o.Mobile, "m1", mobile).
+		NewField(o.Mobile, "m2", Filter(filter.HalfWidth, filter.Strip(" -"), filter.Trim), mobile).
+		NewField(&o.Mobile, "m3", Filter(filter.HalfWidth, filter.Strip(" -")), mobile).
+		NewField([]byte(o.Number), "n1", Filter(filter.HalfWidth), number).
+		NewField([]rune(o.Number), "n2", Filter(filter.HalfWidth), number).
+		NewField(5, "n3", Filter(filter.HalfWidth), number)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"m1": {"mobile"},
+	})
+	a.Equal(o.Mobile, "+8613800138000") // 写回了 o.Mobile
+	a.Equal(o.Number, "１２３")
+
+	// nil
+	var nilStr *string
+	v = New(ContinueAtError, 10).NewField(nilStr, "m", Filter(filter.Trim), OmitNil(), mobile)
+	a.Empty(v.Messages())
+
+	// 分组
+	v = New(ContinueAtError, 10, WithGroups("create")).
+		NewField(" 13800138000 ", "m", Filter(filter.Trim), mobile.Group("create"))
+	a.Empty(v.Messages())
+}
+
+func TestDefault(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	min1 := NewRule(validator.Min(1), "min-1")
+	max10 := NewRule(validator.Max(10), "max-10")
+
+	type object struct {
+		Page  int
+		Size  *int
+		Size2 *int
+		Name  string
+	}
+
+	size2 := 20
+	o := &object{Size2: &size2}
+	v := New(ContinueAtError, 10).
+		NewField(&o.Page, "page", Default(1), min1).
+		NewField(&o.Size, "size", Default(5), NewRule(validator.Required(false), "required")).
+		NewField(&o.Size2, "size2", Default(5)).
+		NewField(&o.Name, "name", Default("name"), NewRule(validator.In("name"), "in")).
+		NewField(0, "f1", Default(1), min1).
+		NewField(20, "f2", Default(1), max10)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f2": {"max-10"},
+	})
+	a.Equal(o.Page, 1).
+		Equal(*o.Size, 5).
+		Equal(*o.Size2, 20).
+		Equal(o.Name, "name")
+
+	// 非零值不会被修改
+	o = &object{Page: 20}
+	v = New(ContinueAtError, 10).NewField(&o.Page, "page", Default(1), max10)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"page": {"max-10"},
+	})
+	a.Equal(o.Page, 20)
+
+	a.Panic(func() {
+		New(ContinueAtError, 10).NewField(&o.Name, "name", Default(struct{}{}))
+	})
+
+	// 数值不能转换为字符串
+	a.PanicString(func() {
+		New(ContinueAtError, 10).NewField(&o.Name, "name", Default(1))
+	}, "无法用于类型为 string 的字段")
+	a.PanicString(func() {
+		New(ContinueAtError, 10).NewField(&o.Name, "name", Default(nil))
+	}, "nil")
+
+	// 数值类型之间可以转换
+	type float struct{ Rate float64 }
+	f := &float{}
+	a.Empty(New(ContinueAtError, 10).NewField(&f.Rate, "rate", Default(1)).Messages())
+	a.Equal(f.Rate, 1.0)
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+// Schema 可复用的验证方案
+//
+// 将字段、规则以及字段之间的约束预先声明，之后可用于验证任意 *T 类型的对象：
+//
+//	var userSchema = NewSchema[User]().
+//	    Field("name", func(u *User) any { return u.Name }, rules...).
+//	    Field("address", func(u *User) any { return addressSchema.Bind(u.Address) }).
+//	    Check(func(v *Validation, u *User) {
+//	        v.When(u.Type == "company", func(v *Validation) { ... })
+//	    })
+//
+//	v := userSchema.Validate(u, ContinueAtError)
+//
+// Schema 在声明完成之后可以在多个 goroutine 中同时使用，
+// 但是声明的过程，即调用 Field、SliceField、MapField 和 Check 等方法，并不是并发安全的。
+type Schema[T any] struct {
+	fields []func(v *Validation, obj *T)
+}
+
+type boundSchema[T any] struct {
+	s   *Schema[T]
+	obj *T
+}
+
+// NewSchema 声明验证类型为 T 的 Schema 对象
+func NewSchema[T any]() *Schema[T] { return &Schema[T]{} }
+
+// Field 声明普通字段的验证
+//
+// get 用于从对象中获取字段的值，如果规则中包含 Filter 和 Default 等需要写回值的规则，应该返回字段的指针；
+// 其它参数与 Validation.NewField 相同。
+func (s *Schema[T]) Field(name string, get func(*T) any, rules ...*Rule) *Schema[T] {
+	return s.Check(func(v *Validation, obj *T) { v.NewField(get(obj), name, rules...) })
+}
+
+// SliceField 声明数组字段的验证
+//
+// 参数与 Validation.NewSliceField 相同，get 用于从对象中获取字段的值。
+func (s *Schema[T]) SliceField(name string, get func(*T) any, rules ...*Rule) *Schema[T] {
+	return s.Check(func(v *Validation, obj *T) { v.NewSliceField(get(obj), name, rules...) })
+}
+
+// MapField 声明 map 字段的验证
+//
+// 参数与 Validation.NewMapField 相同，get 用于从对象中获取字段的值。
+func (s *Schema[T]) MapField(name string, get func(*T) any, rules ...*Rule) *Schema[T] {
+	return s.Check(func(v *Validation, obj *T) { v.NewMapField(get(obj), name, rules...) })
+}
+
+// Check 声明自定义的验证
+//
+// 一般用于字段之间存在关联的验证，f 中的 v 已经指向当前对象的路径，
+// 可以调用 When、NewGroupField 和 NewUnionField 等方法。
+// f 应该只读取 obj 的内容，不应该保存 v 和 obj 的引用。
+func (s *Schema[T]) Check(f func(v *Validation, obj *T)) *Schema[T] {
+	s.fields = append(s.fields, f)
+	return s
+}
+
+// Validate 验证 obj 并返回验证结果
+//
+// errHandling 和 opts 与 New 的参数相同。
+func (s *Schema[T]) Validate(obj *T, errHandling ErrorHandling, opts ...Option) *Validation {
+	return s.Apply(New(errHandling, len(s.fields), opts...), obj)
+}
+
+// Apply 以 v 验证 obj
+//
+// 验证结果将写入 v，obj 中的字段都将以 v 的当前路径作为其父路径；
+// 如果 obj 为 nil，则不作任何验证。
+func (s *Schema[T]) Apply(v *Validation, obj *T) *Validation {
+	if obj == nil {
+		return v
+	}
+
+	for _, f := range s.fields {
+		if v.exited() {
+			break
+		}
+		f(v, obj)
+	}
+	return v
+}
+
+// Bind 将 obj 与当前的 Schema 绑定为 FieldsValidator
+//
+// 可用于在其它 Schema 或 Validation 中验证嵌套的对象：
+//
+//	v.NewField(addressSchema.Bind(u.Address), "address")
+func (s *Schema[T]) Bind(obj *T) FieldsValidator { return &boundSchema[T]{s: s, obj: obj} }
+
+func (b *boundSchema[T]) ValidateFields(v *Validation) { b.s.Apply(v, b.obj) }
+
+func (b *boundSchema[T]) unwrap() any { return b.obj }
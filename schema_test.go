@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/filter"
+	"github.com/issue9/validation/validator"
+)
+
+type (
+	schemaAddress struct {
+		City string
+	}
+
+	schemaUser struct {
+		Name     string
+		Age      int
+		Password string
+		Confirm  string
+		Tags     []string
+		Address  *schemaAddress
+	}
+)
+
+var schemaAddressSchema = NewSchema[schemaAddress]().
+	Field("city", func(a *schemaAddress) any { return a.City }, NewRule(validator.Required(false), "required"))
+
+var schemaUserSchema = NewSchema[schemaUser]().
+	Field("name", func(u *schemaUser) any { return &u.Name }, Filter(filter.Trim), NewRule(validator.Required(false), "required")).
+	Field("age", func(u *schemaUser) any { return u.Age }, NewRule(validator.Min(18), "min")).
+	SliceField("tags", func(u *schemaUser) any { return u.Tags }, NewRule(validator.MinLength(2), "min-length")).
+	Field("address", func(u *schemaUser) any { return schemaAddressSchema.Bind(u.Address) }).
+	Check(func(v *Validation, u *schemaUser) {
+		v.When(u.Password != u.Confirm, func(v *Validation) {
+			v.NewField(u.Confirm, "confirm", NewRule(validator.In(u.Password), "not-match"))
+		})
+	})
+
+func TestSchema_Validate(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	u := &schemaUser{Name: "  ", Age: 5, Password: "1", Tags: []string{"a", "abc"}, Address: &schemaAddress{}}
+	a.Equal(schemaUserSchema.Validate(u, ContinueAtError).LocaleMessages(p), LocaleMessages{
+		"name":         {"required"},
+		"age":          {"min"},
+		"tags[0]":      {"min-length"},
+		"address.city": {"required"},
+		"confirm":      {"not-match"},
+	})
+	a.Empty(u.Name)
+
+	a.Equal(schemaUserSchema.Validate(u, ExitAtError).LocaleMessages(p), LocaleMessages{
+		"name": {"required"},
+	})
+
+	u = &schemaUser{Name: " name ", Age: 18, Password: "1", Confirm: "1"}
+	a.Empty(schemaUserSchema.Validate(u, ContinueAtError).Messages())
+	a.Equal(u.Name, "name")
+
+	// 选项
+	u = &schemaUser{Name: "name", Age: 18, Address: &schemaAddress{}}
+	a.Equal(schemaUserSchema.Validate(u, ContinueAtError, WithPath(JSONPointer)).LocaleMessages(p), LocaleMessages{
+		"/address/city": {"required"},
+	})
+
+	// nil
+	a.Empty(schemaUserSchema.Validate(nil, ContinueAtError).Messages())
+}
+
+func TestSchema_Apply(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	v := New(ContinueAtError, 10).
+		NewField(0, "id", NewRule(validator.Min(1), "min")).
+		Nested("users", func(v *Validation) {
+			schemaUserSchema.Apply(v, &schemaUser{Name: "name", Age: 18})
+		}).
+		NewSliceField([]FieldsValidator{schemaAddressSchema.Bind(&schemaAddress{}), schemaAddressSchema.Bind(nil)}, "addresses")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"id":                {"min"},
+		"addresses[0].city": {"required"},
+	})
+}
+
+func TestSchema_concurrent(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(age int) {
+			defer wg.Done()
+			msgs := schemaUserSchema.Validate(&schemaUser{Name: "name", Age: age}, ContinueAtError).LocaleMessages(p)
+			if age < 18 {
+				a.Equal(msgs, LocaleMessages{"age": {"min"}})
+			} else {
+				a.Empty(msgs)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"github.com/issue9/localeutil"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/is"
+)
+
+type (
+	// SelfValidator 可以验证自身的值
+	//
+	// 字段的值如果实现了此接口，无论是通过 NewField 还是 Field 声明的，在验证该字段时都会自动调用，返回的错误将作为该字段的错误信息，
+	// 如果错误实现了 localeutil.LocaleStringer，则会以本地化的形式输出，否则直接输出 Error() 的内容。
+	//
+	//	type Mobile string
+	//
+	//	func (m Mobile) Validate() error {
+	//	    if !is.CNMobile(string(m)) {
+	//	        return localeutil.Error("无效的手机号码")
+	//	    }
+	//	    return nil
+	//	}
+	SelfValidator interface {
+		Validate() error
+	}
+
+	// SelfChecker 可以判断自身是否合法
+	//
+	// 与 SelfValidator 相同，只不过验证失败时以 WithInvalidMessage 指定的内容作为错误信息。
+	// 如果同时实现了 SelfValidator，则只会调用 SelfValidator。
+	SelfChecker interface {
+		IsValid() bool
+	}
+
+	errorMessage struct{ err error }
+)
+
+// WithInvalidMessage 指定 SelfChecker 验证失败时的错误信息
+//
+// 默认为 localeutil.Phrase("invalid value")。
+func WithInvalidMessage(msg localeutil.LocaleStringer) Option {
+	return func(v *Validation) { v.invalidMessage = msg }
+}
+
+// 如果 val 实现了 SelfValidator 或 SelfChecker，则以其验证 val
+//
+// name 为字段的完整路径，值为 nil 的指针等不作验证。
+func (v *Validation) validateSelf(val any, name string) bool {
+	if is.Nil(val) {
+		return true
+	}
+
+	switch vv := val.(type) {
+	case SelfValidator:
+		if err := vv.Validate(); err != nil {
+			v.messages.Add(name, newErrorMessage(err))
+			return false
+		}
+	case SelfChecker:
+		if !vv.IsValid() {
+			v.messages.Add(name, v.invalidMessage)
+			return false
+		}
+	}
+	return true
+}
+
+func newErrorMessage(err error) localeutil.LocaleStringer {
+	if ls, ok := err.(localeutil.LocaleStringer); ok {
+		return ls
+	}
+	return errorMessage{err: err}
+}
+
+func (msg errorMessage) LocaleString(*message.Printer) string { return msg.err.Error() }
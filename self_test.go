@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/issue9/assert/v2"
+	"github.com/issue9/localeutil"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+
+	"github.com/issue9/validation/is"
+	"github.com/issue9/validation/validator"
+)
+
+type (
+	selfMobile string
+
+	selfIDCard string
+
+	selfMoney struct {
+		Amount int
+	}
+)
+
+func (m selfMobile) Validate() error {
+	if !is.CNMobile(string(m)) {
+		return localeutil.Error("invalid mobile")
+	}
+	return nil
+}
+
+func (c selfIDCard) Validate() error {
+	if !is.GB11643(string(c)) {
+		return errors.New("invalid id card")
+	}
+	return nil
+}
+
+func (m *selfMoney) IsValid() bool { return m.Amount >= 0 }
+
+func TestValidation_self(t *testing.T) {
+	a := assert.New(t, false)
+
+	b := catalog.NewBuilder()
+	a.NotError(b.SetString(language.Chinese, "invalid mobile", "无效的手机号码"))
+	a.NotError(b.SetString(language.Chinese, "invalid value", "无效的值"))
+	p := message.NewPrinter(language.Chinese, message.Catalog(b))
+
+	var nilMoney *selfMoney
+	v := New(ContinueAtError, 10).
+		NewField(selfMobile("123"), "mobile").
+		NewField(selfMobile("13800138000"), "mobile2").
+		NewField(selfIDCard("123"), "idcard", NewRule(validator.MinLength(5), "min-length")).
+		NewField(&selfMoney{Amount: -1}, "money").
+		NewField(nilMoney, "nil").
+		NewField(selfMobile(""), "optional", OmitEmpty(), NewRule(validator.MinLength(5), "min-length"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"mobile": {"无效的手机号码"},
+		"idcard": {"invalid id card", "min-length"},
+		"money":  {"无效的值"},
+	})
+
+	v = New(ExitFieldAtError, 10, WithInvalidMessage(localeutil.Phrase("invalid money"))).
+		NewField(selfIDCard("123"), "idcard", NewRule(validator.MinLength(5), "min-length")).
+		NewSliceField([]*selfMoney{{Amount: 1}, {Amount: -1}}, "money")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"idcard":   {"invalid id card"},
+		"money[1]": {"invalid money"},
+	})
+
+	// 结构体标签
+	type object struct {
+		Mobile selfMobile `validate:"required"`
+		Money  *selfMoney `validate:"omitnil"`
+		Nil    *selfMoney `validate:"omitnil"`
+	}
+	v = New(ContinueAtError, 10).NewStructField(&object{Mobile: "123", Money: &selfMoney{Amount: -1}}, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"Mobile": {"无效的手机号码"},
+		"Money":  {"无效的值"},
+	})
+}
+
+func TestFieldBuilder_self(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	v := New(ContinueAtError, 10)
+	v.Field(selfMobile("123"), "mobile").Validation()
+	v.Field(selfIDCard("123"), "idcard").MinLength(5, "min-length").Validation()
+	v.Field(&selfMoney{Amount: -1}, "money").Required("required").Validation()
+	v.Field(selfMobile(""), "optional").OmitEmpty().MinLength(5, "min-length").Validation()
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"mobile": {"invalid mobile"},
+		"idcard": {"invalid id card", "min-length"},
+		"money":  {"invalid value"},
+	})
+
+	v = New(ExitFieldAtError, 10)
+	v.Field(selfIDCard("123"), "idcard").MinLength(5, "min-length").Validation()
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"idcard": {"invalid id card"},
+	})
+}
+
+type selfCounter struct{ count *int }
+
+func (c selfCounter) Validate() error {
+	*c.count++
+	return nil
+}
+
+func TestValidation_self_once(t *testing.T) {
+	a := assert.New(t, false)
+
+	count := 0
+	c := selfCounter{count: &count}
+	New(ContinueAtError, 10).Field(c, "c").OmitNil().Filter().Default(c).Required("required").Validation()
+	a.Equal(count, 1)
+
+	count = 0
+	New(ContinueAtError, 10).NewField(c, "c", OmitNil(), Default(c))
+	a.Equal(count, 1)
+}
@@ -0,0 +1,419 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/issue9/localeutil"
+
+	"github.com/issue9/validation/validator"
+)
+
+// Tag 结构体中用于声明验证规则的标签名称
+//
+// 标签中的多条规则以逗号分隔，规则名称与参数之间以等号分隔，多个参数之间以空格分隔：
+//
+//	type Object struct {
+//	    Type   string
+//	    IDCard string `validate:"required_if=Type person"`
+//	}
+//
+// 规则对应的错误信息即为规则的名称，可以通过 golang.org/x/text/message/catalog 对其进行翻译。
+// 字段的显示名称可由 LabelTag 指定，通过 RegisterTagRule 注册的规则可以使用 Label 引用该名称。
+//
+// 另外还有以下几个选项，无论出现在什么位置，都会作用于该字段的所有规则：
+//   - omitempty 字段为空时跳过该字段的验证，相当于 OmitEmpty；
+//   - omitnil 字段为 nil 时跳过该字段的验证，相当于 OmitNil；
+//   - default=xx 字段为零值时将其设置为 xx，相当于 Default，仅在结构体以指针形式传递时才会写回字段；
+const Tag = "validate"
+
+// TagRuleFunc 根据结构体标签中的内容生成验证规则
+//
+// obj 为字段所在的结构体，可用于获取其它字段的值；
+// args 为标签中该规则的参数。
+type TagRuleFunc func(obj reflect.Value, args []string) (*Rule, error)
+
+var tagRules = map[string]TagRuleFunc{
+	"required":         tagRequired,
+	"required_if":      tagRequiredIf,
+	"required_unless":  tagRequiredUnless,
+	"required_with":    tagRequiredWith,
+	"required_without": tagRequiredWithout,
+	"excluded_if":      tagExcludedIf,
+}
+
+// RegisterTagRule 注册可在结构体标签中使用的规则
+//
+// 如果 name 已经存在，则会覆盖原有的规则。
+// NOTE: 非并发安全，应该在 init 中进行注册。
+func RegisterTagRule(name string, f TagRuleFunc) { tagRules[name] = f }
+
+// NewStructField 根据结构体标签验证结构体中的字段
+//
+// val 必须是结构体或是指向结构体的指针，如果是 nil 指针，则不作任何处理；
+// name 为结构体的名称，将作为其字段的父路径，为空表示其字段直接位于当前路径之下。
+//
+// 如果字段本身也是结构体，或是元素为结构体的数组和 map，会继续验证其子字段；
+// 如果字段实现了 FieldsValidator，则以 FieldsValidator 代替结构体标签验证其子字段。
+//
+// 结构体标签的格式可参考 Tag，字段在错误信息中的名称可以通过 WithFieldName 指定，
+// 但是标签中引用的其它字段，依然采用原始的字段名。
+func (v *Validation) NewStructField(val any, name string) *Validation {
+	if v.exited() {
+		return v
+	}
+
+	rv := reflect.ValueOf(val)
+	var ptr reflect.Value
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return v
+		}
+		ptr = rv
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic("参数 val 必须是结构体")
+	}
+
+	v.recurse(ptr, v.fieldName(name), func(v *Validation) { v.validateStruct(rv) })
+	return v
+}
+
+func (v *Validation) validateStruct(rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		if v.exited() {
+			return
+		}
+
+		f := rt.Field(i)
+		tag := f.Tag.Get(Tag)
+		if tag == "-" {
+			continue
+		}
+
+		name, flatten := v.structFieldName(f)
+		if name == "" && !flatten {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if flatten { // 匿名嵌入的结构体
+			if f.IsExported() && !v.validateField(fv, v.fieldName(""), v.parseTag(rv, f, tag)) {
+				continue
+			}
+
+			for fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				v.validateStruct(fv)
+			}
+			continue
+		}
+
+		if name = v.fieldName(name); v.absent(name) {
+			// 字段本身不存在，但是其子字段依然可能存在，由子字段自行判断。
+			v.validateValue(fv, name)
+			continue
+		}
+		if label := f.Tag.Get(LabelTag); label != "" {
+			v.setLabel(name, localeutil.Phrase(label))
+		}
+		if !v.validateField(fv, name, v.parseTag(rv, f, tag)) {
+			continue
+		}
+
+		if _, ok := fv.Interface().(FieldsValidator); !ok {
+			v.validateValue(fv, name)
+		}
+	}
+}
+
+func (v *Validation) parseTag(rv reflect.Value, f reflect.StructField, tag string) []*Rule {
+	rules, err := parseTag(rv, f.Type, tag)
+	if err != nil {
+		panic(fmt.Sprintf("字段 %s 的标签解析出错：%s", f.Name, err))
+	}
+	return rules
+}
+
+// 验证结构体字段
+//
+// 如果第一条规则需要写回字段的值，且 fv 可寻址，则以指针的形式传递给规则。
+func (v *Validation) validateField(fv reflect.Value, name string, rules []*Rule) bool {
+	if len(rules) > 0 && rules[0].ptr && fv.CanAddr() {
+		return v.validate(fv.Addr().Interface(), name, rules)
+	}
+	return v.validate(fv.Interface(), name, rules)
+}
+
+// 如果 rv 是结构体或是元素为结构体的数组和 map，则验证其子字段
+//
+// 实现了 FieldsValidator 的值由 FieldsValidator 验证，其它则根据结构体标签进行验证。
+func (v *Validation) validateValue(rv reflect.Value, name string) {
+	if v.exited() || !hasFields(rv.Type()) {
+		return
+	}
+
+	var ptr reflect.Value
+	for {
+		isPtr := rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface
+		if isPtr && rv.IsNil() {
+			return
+		}
+
+		if fv, ok := rv.Interface().(FieldsValidator); ok {
+			v.recurse(rv, name, fv.ValidateFields)
+			return
+		}
+
+		if !isPtr {
+			break
+		}
+		if rv.Kind() == reflect.Ptr {
+			ptr = rv
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		v.recurse(ptr, name, func(v *Validation) { v.validateStruct(rv) })
+	case reflect.Array, reflect.Slice:
+		leave, ok := v.visit(rv) // 切片可能包含其自身，比如 []any
+		if !ok {
+			return
+		}
+		defer leave()
+
+		for i := 0; i < rv.Len(); i++ {
+			v.validateValue(rv.Index(i), v.path.Index(name, i))
+		}
+	case reflect.Map:
+		leave, ok := v.visit(rv)
+		if !ok {
+			return
+		}
+		defer leave()
+
+		for _, key := range sortMapKeys(rv) {
+			v.validateValue(rv.MapIndex(key), v.path.Key(name, formatMapKey(key)))
+		}
+	}
+}
+
+// 类型 t 是否可能包含需要验证的子字段
+func hasFields(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Interface:
+		return true
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return hasFields(t.Elem())
+	default:
+		return false
+	}
+}
+
+// 解析结构体标签
+//
+// obj 为字段所在的结构体，t 为字段的类型。
+func parseTag(obj reflect.Value, t reflect.Type, tag string) ([]*Rule, error) {
+	if tag == "" {
+		return nil, nil
+	}
+
+	items := strings.Split(tag, ",")
+	rules := make([]*Rule, 0, len(items))
+	var omit, def *Rule
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		switch item {
+		case "":
+			continue
+		case "omitempty":
+			omit = OmitEmpty()
+			continue
+		case "omitnil":
+			omit = OmitNil()
+			continue
+		}
+
+		name, args, _ := strings.Cut(item, "=")
+		if name == "default" {
+			val, err := parseDefault(t, args)
+			if err != nil {
+				return nil, err
+			}
+			def = Default(val)
+			continue
+		}
+
+		f, found := tagRules[name]
+		if !found {
+			return nil, fmt.Errorf("不存在的规则 %s", name)
+		}
+
+		rule, err := f(obj, strings.Fields(args))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if omit != nil {
+		rules = append([]*Rule{omit}, rules...)
+	}
+	if def != nil {
+		rules = append([]*Rule{def}, rules...)
+	}
+	return rules, nil
+}
+
+// 将字符串 s 转换为类型 t 的值，如果 t 为指针，则转换为其指向的类型。
+func parseDefault(t reflect.Type, s string) (any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return time.ParseDuration(s)
+	}
+
+	val := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		val.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		val.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		val.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return nil, err
+		}
+		val.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		val.SetBool(b)
+	default:
+		return nil, fmt.Errorf("不支持为类型 %s 指定默认值", t)
+	}
+	return val.Interface(), nil
+}
+
+func tagRequired(_ reflect.Value, _ []string) (*Rule, error) {
+	return NewRule(validator.Required(false), "required"), nil
+}
+
+func tagRequiredIf(obj reflect.Value, args []string) (*Rule, error) {
+	other, values, err := tagCondition(obj, args)
+	if err != nil {
+		return nil, err
+	}
+	return NewRule(validator.RequiredIf(false, other, values...), "required_if"), nil
+}
+
+func tagRequiredUnless(obj reflect.Value, args []string) (*Rule, error) {
+	other, values, err := tagCondition(obj, args)
+	if err != nil {
+		return nil, err
+	}
+	return NewRule(validator.RequiredUnless(false, other, values...), "required_unless"), nil
+}
+
+func tagRequiredWith(obj reflect.Value, args []string) (*Rule, error) {
+	others, err := tagFields(obj, args)
+	if err != nil {
+		return nil, err
+	}
+	return NewRule(validator.RequiredWith(false, others...), "required_with"), nil
+}
+
+func tagRequiredWithout(obj reflect.Value, args []string) (*Rule, error) {
+	others, err := tagFields(obj, args)
+	if err != nil {
+		return nil, err
+	}
+	return NewRule(validator.RequiredWithout(false, others...), "required_without"), nil
+}
+
+func tagExcludedIf(obj reflect.Value, args []string) (*Rule, error) {
+	other, values, err := tagCondition(obj, args)
+	if err != nil {
+		return nil, err
+	}
+	return NewRule(validator.ExcludedIf(other, values...), "excluded_if"), nil
+}
+
+// 解析 Field v1 v2 格式的参数
+//
+// 标签中的值都是字符串，所以返回的字段值也会被转换成字符串形式，以便与 values 进行比较。
+func tagCondition(obj reflect.Value, args []string) (other any, values []any, err error) {
+	if len(args) < 2 {
+		return nil, nil, fmt.Errorf("参数 %v 格式不正确", args)
+	}
+
+	f, err := tagField(obj, args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values = make([]any, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		values = append(values, arg)
+	}
+	if rv := reflect.ValueOf(f); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		f = rv.Elem().Interface()
+	}
+	return fmt.Sprint(f), values, nil
+}
+
+func tagFields(obj reflect.Value, names []string) ([]any, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("缺少参数")
+	}
+
+	fields := make([]any, 0, len(names))
+	for _, name := range names {
+		f, err := tagField(obj, name)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func tagField(obj reflect.Value, name string) (any, error) {
+	f := obj.FieldByName(name)
+	if !f.IsValid() || !f.CanInterface() {
+		return nil, fmt.Errorf("字段 %s 不存在", name)
+	}
+	return f.Interface(), nil
+}
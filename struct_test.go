@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/issue9/validation/validator"
+)
+
+type tagObject struct {
+	Type       string
+	IDCard     string `validate:"required_if=Type person"`
+	CreditCode string `validate:"required_if=Type company,excluded_if=Type person"`
+	Mobile     string `validate:"required_without=Email"`
+	Email      string `validate:"required_without=Mobile"`
+	Password   string
+	Confirm    string `validate:"required_with=Password"`
+	Name       string `validate:"required_unless=Type anonymous"`
+	Ignore     string `validate:"-"`
+	unexported string `validate:"required"`
+}
+
+func TestValidation_NewStructField(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	obj := &tagObject{Type: "person", CreditCode: "code", Password: "pwd"}
+	v := New(ContinueAtError, 10).NewStructField(obj, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"IDCard":     {"required_if"},
+		"CreditCode": {"excluded_if"},
+		"Mobile":     {"required_without"},
+		"Email":      {"required_without"},
+		"Confirm":    {"required_with"},
+		"Name":       {"required_unless"},
+	})
+
+	obj = &tagObject{Type: "anonymous", Mobile: "13800138000"}
+	v = New(ContinueAtError, 10).NewStructField(obj, "obj")
+	a.Empty(v.Messages())
+
+	obj = &tagObject{Type: "company", Email: "email@example.com", Name: "name"}
+	v = New(ContinueAtError, 10).NewStructField(*obj, "obj")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"obj.CreditCode": {"required_if"},
+	})
+
+	// nil
+	var nilObj *tagObject
+	v = New(ContinueAtError, 10).NewStructField(nilObj, "obj")
+	a.Empty(v.Messages())
+
+	a.PanicString(func() {
+		New(ContinueAtError, 10).NewStructField(5, "obj")
+	}, "参数 val 必须是结构体")
+
+	a.Panic(func() {
+		New(ContinueAtError, 10).NewStructField(&struct {
+			F1 string `validate:"not-exists"`
+		}{}, "obj")
+	})
+
+	a.Panic(func() {
+		New(ContinueAtError, 10).NewStructField(&struct {
+			F1 string `validate:"required_if=F2 v"`
+		}{}, "obj")
+	})
+}
+
+func TestRegisterTagRule(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	RegisterTagRule("min18", func(_ reflect.Value, args []string) (*Rule, error) {
+		if len(args) > 0 {
+			return nil, errors.New("不需要参数")
+		}
+		return NewRule(validator.Min(18), "不能小于 18"), nil
+	})
+	defer delete(tagRules, "min18")
+
+	v := New(ContinueAtError, 10).NewStructField(&struct {
+		Age int `validate:"min18"`
+	}{Age: 5}, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"Age": {"不能小于 18"},
+	})
+
+	a.Panic(func() {
+		New(ContinueAtError, 10).NewStructField(&struct {
+			Age int `validate:"min18=5"`
+		}{Age: 5}, "")
+	})
+}
+
+func TestValidation_NewStructField_omit(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	RegisterTagRule("email", func(reflect.Value, []string) (*Rule, error) {
+		return NewRule(validator.Email, "email"), nil
+	})
+	defer delete(tagRules, "email")
+
+	type object struct {
+		Email1 string  `validate:"email"`
+		Email2 string  `validate:"email,omitempty"`
+		Email3 *string `validate:"omitnil,required"`
+		Email4 *string `validate:"omitnil,email"`
+	}
+
+	empty := ""
+	v := New(ContinueAtError, 10).NewStructField(&object{Email4: &empty}, "")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"Email1": {"email"},
+		"Email4": {"email"},
+	})
+}
+
+func TestValidation_NewStructField_nested(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	type item struct {
+		Name string `validate:"required"`
+	}
+
+	type object struct {
+		Item   item
+		PItem  *item
+		Items  []*item
+		Map    map[string]item
+		Any    any
+		User   *user // FieldsValidator
+		Ignore item  `validate:"-"`
+		Nums   []int `validate:"required"`
+	}
+
+	obj := &object{
+		PItem: &item{Name: "name"},
+		Items: []*item{{Name: "1"}, {}, nil},
+		Map:   map[string]item{"k1": {}, "k2": {Name: "2"}},
+		Any:   &item{},
+		User:  &user{Name: "user", Addresses: []*address{{}}},
+	}
+	v := New(ContinueAtError, 10).NewStructField(obj, "obj")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"obj.Item.Name":              {"required"},
+		"obj.Items[1].Name":          {"required"},
+		"obj.Map[k1].Name":           {"required"},
+		"obj.Any.Name":               {"required"},
+		"obj.User.addresses[0].city": {"required"},
+		"obj.Nums":                   {"required"},
+	})
+
+	v = New(ExitAtError, 10).NewStructField(obj, "obj")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"obj.Item.Name": {"required"},
+	})
+}
+
+func TestValidation_NewStructField_default(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	type object struct {
+		Page     int           `validate:"default=1,required"`
+		Size     *uint8        `validate:"required,default=20"`
+		Name     string        `validate:"default=hello world"`
+		Rate     float32       `validate:"default=0.5"`
+		Enabled  bool          `validate:"default=true"`
+		Timeout  time.Duration `validate:"default=1m"`
+		Optional string        `validate:"omitempty,default=x,required"`
+	}
+
+	o := &object{Name: "name"}
+	v := New(ContinueAtError, 10).NewStructField(o, "")
+	a.Empty(v.Messages())
+	a.Equal(o.Page, 1).
+		Equal(*o.Size, 20).
+		Equal(o.Name, "name").
+		Equal(o.Rate, float32(0.5)).
+		True(o.Enabled).
+		Equal(o.Timeout, time.Minute).
+		Equal(o.Optional, "x")
+
+	// 非指针，无法写回，但是依然以默认值进行验证。
+	v = New(ContinueAtError, 10).NewStructField(object{}, "")
+	a.Empty(v.Messages())
+
+	// 格式错误
+	a.Panic(func() {
+		New(ContinueAtError, 10).NewStructField(&struct {
+			Page int `validate:"default=x"`
+		}{}, "")
+	})
+
+	a.Panic(func() {
+		New(ContinueAtError, 10).NewStructField(&struct {
+			Page []int `validate:"default=1"`
+		}{}, "")
+	})
+
+	a.Equal(New(ContinueAtError, 10).NewStructField(&struct {
+		Page int `validate:"default=-1,required"`
+	}{}, "").LocaleMessages(p), LocaleMessages{})
+}
@@ -5,11 +5,12 @@ package validation
 
 import (
 	"reflect"
-	"strconv"
+	"sort"
 
 	"github.com/issue9/localeutil"
 	"golang.org/x/text/message"
 
+	"github.com/issue9/validation/is"
 	"github.com/issue9/validation/validator"
 )
 
@@ -26,137 +27,507 @@ type (
 	Validation struct {
 		errHandling ErrorHandling
 		messages    Messages
-	}
+		path        Path
+		prefix      string // 当前字段的父路径
 
-	Validator = validator.Validator
+		nameTag string
+		naming  func(string) string
 
-	ValidateFunc = validator.ValidateFunc
+		labels map[string]localeutil.LocaleStringer
+
+		groups []string
 
-	// Rule 验证规则
-	//
-	// 这是对 Validator 的二次包装，保存着未本地化的错误信息，用以在验证失败之后返回给 Validation。
-	Rule struct {
-		validator Validator
-		message   localeutil.LocaleStringer
+		present    map[string]struct{}
+		presentDoc map[string]any
+
+		invalidMessage localeutil.LocaleStringer
+
+		visited      map[visitKey]struct{} // 当前路径上正在验证的对象
+		depth        int
+		maxDepth     int
+		depthMessage localeutil.LocaleStringer
 	}
-)
 
-func NewRule(validator Validator, key message.Reference, v ...any) *Rule {
-	return &Rule{
-		validator: validator,
-		message:   localeutil.Phrase(key, v...),
+	// Option 用于指定 Validation 的选项
+	Option func(*Validation)
+
+	// FieldsValidator 需要验证子字段的对象可以实现此接口
+	FieldsValidator interface {
+		// ValidateFields 验证对象的子字段
+		//
+		// v 的当前路径已经指向该对象，子字段只需要指定其自身的名称即可。
+		ValidateFields(v *Validation)
 	}
-}
+
+	Validator = validator.Validator
+
+	ValidateFunc = validator.ValidateFunc
+)
 
 // New 返回 Validation 对象
 //
 // cap 表示初始的 Messages 容量大小；
-func New(errHandling ErrorHandling, cap int) *Validation {
-	return &Validation{
-		errHandling: errHandling,
-		messages:    make(Messages, cap),
+// opts 为其它的可选项，未指定路径格式时，采用 DotPath。
+func New(errHandling ErrorHandling, cap int, opts ...Option) *Validation {
+	v := &Validation{
+		errHandling:    errHandling,
+		messages:       make(Messages, cap),
+		path:           DotPath,
+		invalidMessage: localeutil.Phrase("invalid value"),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.presentDoc != nil {
+		if v.present == nil {
+			v.present = make(map[string]struct{}, len(v.presentDoc))
+		}
+		v.presentPaths("", v.presentDoc)
+		v.presentDoc = nil
 	}
+	return v
 }
 
+// WithPath 指定字段路径的格式
+func WithPath(p Path) Option { return func(v *Validation) { v.path = p } }
+
 // NewField 验证新的字段
 //
 // val 表示需要被验证的值，如果是一个对象且需要验证子字段，那么让对象实现 FieldsValidator 接口，
 // 则会自动调用该方法验证子项，将会将验证完的信息返回给当前的 Validation 实例；
-// name 表示当前字段的名称，当验证出错时，以此值作为名称返回给用户；
+// name 表示当前字段的名称，当验证出错时，以此值与父路径组成的完整路径作为名称返回给用户；
 // rules 表示验证的规则，按顺序依次验证。
 func (v *Validation) NewField(val any, name string, rules ...*Rule) *Validation {
-	if !v.messages.Empty() && v.errHandling == ExitAtError {
+	if v.exited() {
 		return v
 	}
 
-	for _, rule := range rules {
-		if rule.validator.IsValid(val) {
-			continue
-		}
-
-		v.messages.Add(name, rule.message)
-		if v.errHandling != ContinueAtError {
-			break
-		}
-	}
+	v.validatePresent(val, v.fieldName(name), rules)
 	return v
 }
 
 // NewSliceField 验证数组字段
 //
 // 如果字段类型不是数组或是字符串，将直接返回错误。
+// 字符串会按字符（rune）而不是字节进行遍历，错误信息中的下标也是字符的下标。
 func (v *Validation) NewSliceField(val any, name string, rules ...*Rule) *Validation {
 	// TODO: 如果 go 支持泛型方法，那么可以将 val 固定在 []T
 
-	rv := reflect.ValueOf(val)
+	if v.exited() {
+		return v
+	}
 
+	rv := reflect.ValueOf(val)
+	name = v.fieldName(name)
 	if kind := rv.Kind(); kind != reflect.Array && kind != reflect.Slice && kind != reflect.String {
-		if v.errHandling != ContinueAtError {
-			v.messages.Add(name, rules[0].message) // 非数组，取第一个规则的错误信息
-			return v
-		}
-		for _, rule := range rules {
-			v.messages.Add(name, rule.message)
+		if !v.absent(name) {
+			v.typeMismatch(name, rules)
 		}
 		return v
 	}
 
-	for i := 0; i < rv.Len(); i++ {
-		for _, rule := range rules {
-			if !rule.validator.IsValid(rv.Index(i).Interface()) {
-				v.messages.Add(name+"["+strconv.Itoa(i)+"]", rule.message)
-				if v.errHandling != ContinueAtError {
-					return v
-				}
-			}
+	v.validateElems(rv, name, rules)
+	return v
+}
+
+// NewCollectionField 同时验证数组本身及其元素
+//
+// 与 NewSliceField 不同，rules 用于验证整个数组，比如长度、唯一性和排序等，
+// elemRules 则用于验证数组中的每一个元素，只有在 rules 验证通过或是 ContinueAtError 模式下才会验证元素；
+// 如果字段类型不是数组，则以 mismatch 作为错误信息，而不是从规则中获取。
+func (v *Validation) NewCollectionField(val any, name string, mismatch localeutil.LocaleStringer, rules []*Rule, elemRules ...*Rule) *Validation {
+	if v.exited() {
+		return v
+	}
+
+	rv := reflect.ValueOf(val)
+	name = v.fieldName(name)
+	absent := v.absent(name)
+	if kind := rv.Kind(); kind != reflect.Array && kind != reflect.Slice {
+		if !absent {
+			v.messages.Add(name, mismatch)
 		}
+		return v
+	}
+
+	if !absent && !v.validate(val, name, rules) && v.errHandling != ContinueAtError {
+		return v
 	}
 
+	v.validateElems(rv, name, elemRules)
 	return v
 }
 
 // NewMapField 验证 map 字段
 //
+// 按键名的顺序依次验证每一个键值，以保证每次产生的错误信息都是相同的。
 // 如果字段类型不是 map，将直接返回错误。
 func (v *Validation) NewMapField(val any, name string, rules ...*Rule) *Validation {
 	// TODO: 如果 go 支持泛型方法，那么可以将 val 固定在 map[T]T
 
-	rv := reflect.ValueOf(val)
+	if v.exited() {
+		return v
+	}
 
+	rv := reflect.ValueOf(val)
+	name = v.fieldName(name)
 	if kind := rv.Kind(); kind != reflect.Map {
-		if v.errHandling != ContinueAtError {
-			v.messages.Add(name, rules[0].message) // 非数组，取第一个规则的错误信息
+		if !v.absent(name) {
+			v.typeMismatch(name, rules)
+		}
+		return v
+	}
+
+	validate := v.elemValidator(name)
+	for _, key := range sortMapKeys(rv) {
+		if !validate(rv.MapIndex(key).Interface(), v.path.Key(name, formatMapKey(key)), rules) && v.errHandling != ContinueAtError {
 			return v
 		}
-		for _, rule := range rules {
-			v.messages.Add(name, rule.message)
+	}
+
+	return v
+}
+
+// NewMapKeyField 验证 map 字段的键名
+//
+// 与 NewMapField 相同，只不过验证的对象是键名而不是键值，
+// 如果需要同时验证键名和键值，可以分别调用这两个方法。
+// 键名的路径由 Path.KeyName 生成，DotPath 会在键值路径之后加上 #，比如 map[1]#，
+// 而 SlashPath 和 JSONPointer 无法区分两者，键名与键值的错误信息会出现在同一路径下。
+// 如果字段类型不是 map，将直接返回错误。
+func (v *Validation) NewMapKeyField(val any, name string, rules ...*Rule) *Validation {
+	if v.exited() {
+		return v
+	}
+
+	rv := reflect.ValueOf(val)
+	name = v.fieldName(name)
+	if kind := rv.Kind(); kind != reflect.Map {
+		if !v.absent(name) {
+			v.typeMismatch(name, rules)
 		}
 		return v
 	}
 
-	keys := rv.MapKeys()
-	for i := 0; i < rv.Len(); i++ {
-		key := keys[i]
-		for _, rule := range rules {
-			if !rule.validator.IsValid(rv.MapIndex(key).Interface()) {
-				v.messages.Add(name+"["+key.String()+"]", rule.message)
-				if v.errHandling != ContinueAtError {
-					return v
-				}
+	validate := v.elemValidator(name)
+	for _, key := range sortMapKeys(rv) {
+		if !validate(key.Interface(), v.path.KeyName(name, formatMapKey(key)), rules) && v.errHandling != ContinueAtError {
+			return v
+		}
+	}
+
+	return v
+}
+
+// NewGroupField 将多个字段作为一个整体进行验证
+//
+// fields 为字段名称与值的对应关系，所有的值按字段名称排序之后以 []any 的形式传递给 rules，
+// 可以配合 validator.AtLeastOne、validator.ExactlyOne 和 validator.AtMostOne 等规则使用；
+// name 表示当前字段组的名称，如果为空，错误信息将分别记录在 fields 中的每一个字段之下。
+func (v *Validation) NewGroupField(fields map[string]any, name string, rules ...*Rule) *Validation {
+	if v.exited() {
+		return v
+	}
+
+	names := make([]string, 0, len(fields))
+	for n := range fields {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	vals := make([]any, 0, len(names))
+	for _, n := range names {
+		vals = append(vals, fields[n])
+	}
+
+	for _, rule := range rules {
+		if !v.inGroups(rule) {
+			continue
+		}
+
+		if rule.skip != nil {
+			if rule.skip(vals) {
+				break
+			}
+			continue
+		}
+
+		if rule.validator == nil { // 不支持对字段组进行转换
+			continue
+		}
+
+		if rule.validator.IsValid(vals) {
+			continue
+		}
+
+		stop := v.errHandling != ContinueAtError || rule.bail
+
+		if name != "" {
+			v.addMessage(v.fieldName(name), rule)
+		} else {
+			for _, n := range names {
+				v.addMessage(v.fieldName(n), rule)
 			}
 		}
+
+		if stop {
+			break
+		}
+	}
+	return v
+}
+
+// NewUnionField 根据类型字段的值选择需要验证的分支
+//
+// 适用于由某个类型字段决定其它字段验证规则的对象：
+// disc 为类型字段的值，name 为类型字段的名称；
+// branches 为 disc 的各个可用值及其对应的验证，只会执行与 disc 相匹配的分支，
+// 分支中的 v 即为当前对象，分支中的字段与类型字段处于同一路径之下；
+// 如果 disc 不在 branches 之中，则以 unknown 作为类型字段的错误信息。
+//
+// 在 WithPresent 模式下，即使类型字段本身不存在，依然会执行与 disc 相匹配的分支，
+// 由分支中的字段自行判断是否存在，只是不会产生 unknown 错误信息。
+//
+//	v.NewUnionField(o.Type, "type", localeutil.Phrase("无效的类型"), map[any]func(*Validation){
+//	    "person": func(v *Validation) {
+//	        v.NewField(o.IDCard, "id_card", NewRule(validator.GB11643, "无效的身份证"))
+//	    },
+//	    "company": func(v *Validation) {
+//	        v.NewField(o.CreditCode, "credit_code", NewRule(validator.GB32100, "无效的信用代码"))
+//	    },
+//	})
+func (v *Validation) NewUnionField(disc any, name string, unknown localeutil.LocaleStringer, branches map[any]func(v *Validation)) *Validation {
+	if v.exited() {
+		return v
+	}
+
+	f := lookupCase(branches, disc)
+	if f == nil {
+		if full := v.fieldName(name); !v.absent(full) {
+			v.messages.Add(full, unknown)
+		}
+		return v
+	}
+
+	f(v)
+	return v
+}
+
+// Nested 在子路径 name 之下执行 f 中的验证
+//
+// f 中的 v 即为当前对象，在 f 中声明的字段都将以 name 作为其父路径：
+//
+//	v.Nested("address", func(v *Validation) {
+//	    v.NewField(o.Address.City, "city", rules...) // 字段名称为 address.city
+//	})
+func (v *Validation) Nested(name string, f func(v *Validation)) *Validation {
+	if v.exited() {
+		return v
 	}
 
+	v.descend(v.fieldName(name), f)
 	return v
 }
 
-// When 只有满足 cond 才执行 f 中的验证
+// 以 path 作为父路径执行 f
+func (v *Validation) descend(path string, f func(v *Validation)) {
+	prefix := v.prefix
+	v.prefix = path
+	defer func() { v.prefix = prefix }()
+	f(v)
+}
+
+// 返回字段 name 的完整路径
+func (v *Validation) fieldName(name string) string {
+	if name == "" {
+		return v.prefix
+	}
+	return v.path.Field(v.prefix, name)
+}
+
+func (v *Validation) exited() bool { return v.errHandling == ExitAtError && !v.messages.Empty() }
+
+// 与 validate 相同，但是字段 name 不存在时，只验证其子字段
+func (v *Validation) validatePresent(val any, name string, rules []*Rule) bool {
+	if !v.absent(name) {
+		return v.validate(val, name, rules)
+	}
+
+	// 字段本身不存在，但是其子字段依然可能存在，由子字段自行判断。
+	if fv, ok := val.(FieldsValidator); ok && !is.Nil(val) {
+		v.recurse(reflect.ValueOf(val), name, fv.ValidateFields)
+	}
+	return true
+}
+
+// 返回验证集合 name 中元素的方法
 //
-// f 中的 v 即为当前对象；
-func (v *Validation) When(cond bool, f func(v *Validation)) *Validation {
-	if cond {
-		f(v)
+// 集合本身存在时，其元素被当作整体的一部分，都会被验证；
+// 否则需要分别判断每个元素是否存在。
+func (v *Validation) elemValidator(name string) func(any, string, []*Rule) bool {
+	if v.absent(name) {
+		return v.validatePresent
+	}
+	return v.validate
+}
+
+// 依次以 rules 验证 val，返回值表示是否验证通过
+//
+// name 为字段的完整路径；
+// 如果 val 实现了 SelfValidator 或 SelfChecker，会在 rules 中的第一条验证规则之前执行，
+// 位于其之前的 OmitEmpty 和 Filter 等处理字段值的规则依然有效；
+// 如果所有规则都验证通过，且 val 实现了 FieldsValidator，则会继续验证其子字段。
+func (v *Validation) validate(val any, name string, rules []*Rule) bool {
+	f := &fieldState{val: val, name: name, ok: true}
+	for _, rule := range rules {
+		if v.validateRule(f, rule); f.done {
+			break
+		}
+	}
+	return v.validateRest(f)
+}
+
+// 单个字段的验证状态
+type fieldState struct {
+	val     any
+	name    string
+	checked bool // 是否已经执行了 SelfValidator 或 SelfChecker
+	ok      bool // 之前的规则是否都验证通过
+	skipped bool // 是否被 OmitEmpty 等规则跳过
+	done    bool // 是否不再验证之后的规则
+}
+
+// 以 rule 验证 f
+func (v *Validation) validateRule(f *fieldState, rule *Rule) {
+	if !v.inGroups(rule) {
+		return
+	}
+
+	if rule.validator == nil && rule.skip == nil && rule.transform == nil { // FieldBuilder.Bail
+		f.done = rule.bail && !f.ok
+		return
 	}
+
+	if rule.skip != nil {
+		if rule.skip(f.val) {
+			f.skipped = true
+			f.done = true
+		}
+		return
+	}
+
+	if rule.transform != nil {
+		var converted bool
+		if f.val, converted = rule.transform(f.val); !converted {
+			v.addMessage(f.name, rule)
+			f.ok = false
+			f.done = true
+		}
+		return
+	}
+
+	if !f.checked {
+		f.checked = true
+		if !v.validateSelf(f.val, f.name) {
+			f.ok = false
+			if v.errHandling != ContinueAtError {
+				f.done = true
+				return
+			}
+		}
+	}
+
+	if rule.validator.IsValid(f.val) {
+		return
+	}
+
+	v.addMessage(f.name, rule)
+	f.ok = false
+	f.done = v.errHandling != ContinueAtError || rule.bail
+}
+
+// 在所有规则之后对 f 进行的验证，返回值表示 f 是否验证通过
+//
+// 如果之前没有执行 SelfValidator 或 SelfChecker，则在此处执行；
+// 如果验证通过且 f 实现了 FieldsValidator，则继续验证其子字段。
+func (v *Validation) validateRest(f *fieldState) bool {
+	if f.skipped {
+		return true
+	}
+
+	if !f.ok {
+		return false
+	}
+
+	if !f.checked {
+		f.checked = true
+		if !v.validateSelf(f.val, f.name) {
+			f.ok = false
+			return false
+		}
+	}
+
+	if fv, isFV := f.val.(FieldsValidator); isFV && !is.Nil(f.val) {
+		size := v.messages.Len()
+		v.recurse(reflect.ValueOf(f.val), f.name, fv.ValidateFields)
+		return size == v.messages.Len()
+	}
+	return true
+}
+
+// 依次验证数组 rv 中的每一个元素
+//
+// 如果 rv 是字符串，则按 rune 进行遍历。
+func (v *Validation) validateElems(rv reflect.Value, name string, rules []*Rule) {
+	if rv.Kind() == reflect.String {
+		rv = reflect.ValueOf([]rune(rv.String()))
+	}
+
+	validate := v.elemValidator(name)
+	for i := 0; i < rv.Len(); i++ {
+		if !validate(rv.Index(i).Interface(), v.path.Index(name, i), rules) && v.errHandling != ContinueAtError {
+			return
+		}
+	}
+}
+
+// 类型不匹配时，以 rules 中的错误信息作为 name 的错误信息
+func (v *Validation) typeMismatch(name string, rules []*Rule) {
+	for _, rule := range rules {
+		if rule.validator == nil || !v.inGroups(rule) {
+			continue
+		}
+
+		v.addMessage(name, rule)
+		if v.errHandling != ContinueAtError {
+			return // 非数组，取第一个规则的错误信息
+		}
+	}
+}
+
+// OverrideErrorHandling 在 f 中以 h 代替当前的错误处理方式
+//
+// 用于为部分字段指定不同的错误处理方式，f 中的 v 即为当前对象，
+// 执行完 f 之后会恢复原来的错误处理方式：
+//
+//	v := New(ContinueAtError, 10).
+//	    OverrideErrorHandling(ExitFieldAtError, func(v *Validation) {
+//	        v.NewField(o.Avatar, "avatar", rules...) // 只返回第一个错误
+//	    })
+//
+// 如果当前处于 ExitAtError 模式且已经有错误信息，f 将不会被执行。
+// 在 f 中使用 ExitAtError 时，与整个 Validation 对象的其它字段也是相关的，
+// 如果只是想中断当前字段的验证，应该使用 ExitFieldAtError。
+func (v *Validation) OverrideErrorHandling(h ErrorHandling, f func(v *Validation)) *Validation {
+	if v.exited() {
+		return v
+	}
+
+	old := v.errHandling
+	v.errHandling = h
+	defer func() { v.errHandling = old }()
+	f(v)
 	return v
 }
 
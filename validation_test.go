@@ -29,8 +29,28 @@ type (
 		Name string
 		Age  int
 	}
+
+	address struct {
+		City string
+		Tags []string
+	}
+
+	user struct {
+		Name      string
+		Addresses []*address
+	}
 )
 
+func (addr *address) ValidateFields(v *Validation) {
+	v.NewField(addr.City, "city", NewRule(validator.Required(false), "required")).
+		NewSliceField(addr.Tags, "tags", NewRule(validator.MinLength(2), "min-length"))
+}
+
+func (u *user) ValidateFields(v *Validation) {
+	v.NewField(u.Name, "name", NewRule(validator.Required(false), "required")).
+		NewSliceField(u.Addresses, "addresses")
+}
+
 func TestValidation_ErrorHandling(t *testing.T) {
 	a := assert.New(t, false)
 	p := message.NewPrinter(language.Chinese)
@@ -144,6 +164,55 @@ func TestValidation_NewSliceField(t *testing.T) {
 	a.Equal(v.LocaleMessages(p), LocaleMessages{
 		"slice[0]": []string{"min-5"},
 	})
+
+	// 字符串按字符遍历
+	v = New(ContinueAtError, 10).
+		NewSliceField("中a文", "str", NewRule(validator.In('中', '文'), "in"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"str[1]": []string{"in"},
+	})
+}
+
+func TestValidation_NewCollectionField(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.SimplifiedChinese)
+
+	mismatch := localeutil.Phrase("mismatch")
+	length := NewRule(validator.Length(1, 3), "length")
+	unique := NewRule(validator.Unique, "unique")
+	elemLength := NewRule(validator.Length(2, 5), "elem-length")
+
+	v := New(ContinueAtError, 10).
+		NewCollectionField(123456, "tags", mismatch, []*Rule{length, unique}, elemLength)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"tags": []string{"mismatch"},
+	})
+
+	v = New(ContinueAtError, 10).
+		NewCollectionField([]string{"abc", "a", "abc", "abcdef"}, "tags", mismatch, []*Rule{length, unique}, elemLength)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"tags":    []string{"length", "unique"},
+		"tags[1]": []string{"elem-length"},
+		"tags[3]": []string{"elem-length"},
+	})
+
+	v = New(ExitFieldAtError, 10).
+		NewCollectionField([]string{"abc", "a", "abc", "abcdef"}, "tags", mismatch, []*Rule{length, unique}, elemLength)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"tags": []string{"length"},
+	})
+
+	v = New(ExitFieldAtError, 10).
+		NewCollectionField([]string{"abc", "a", "abcdef"}, "tags", mismatch, []*Rule{length, unique}, elemLength)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"tags[1]": []string{"elem-length"},
+	})
+
+	v = New(ContinueAtError, 10).
+		NewCollectionField([2]int{1, 2}, "tags", mismatch, []*Rule{NewRule(validator.Contains(1, 3), "contains"), NewRule(validator.Sorted, "sorted")})
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"tags": []string{"contains"},
+	})
 }
 
 func TestValidation_NewMapField(t *testing.T) {
@@ -182,35 +251,205 @@ func TestValidation_NewMapField(t *testing.T) {
 	// ExitAtError
 	v = New(ExitAtError, 10).
 		NewMapField(map[string]int{"0": 1, "2": 2, "6": 6}, "map", min5)
-	a.Length(v.LocaleMessages(p), 1) // map 顺序未定
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"map[0]": []string{"min-5"},
+	})
+
+	// 非字符串的键名
+	v = New(ContinueAtError, 10).
+		NewMapField(map[int]int{10: 1, 2: 2, 6: 6}, "map", min5)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"map[10]": []string{"min-5"},
+		"map[2]":  []string{"min-5"},
+	})
+
+	// 键名的顺序
+	for i := 0; i < 10; i++ {
+		v = New(ExitAtError, 10).
+			NewMapField(map[int]int{10: 1, 2: 2, 6: 6}, "map", min5)
+		a.Equal(v.LocaleMessages(p), LocaleMessages{
+			"map[2]": []string{"min-5"},
+		})
+	}
 }
 
-func TestValidation_When(t *testing.T) {
+func TestValidation_NewMapKeyField(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.SimplifiedChinese)
+
+	min5 := NewRule(validator.Min(5), "min-5")
+	max50 := NewRule(validator.Max(50), "max-50")
+
+	v := New(ContinueAtError, 10).
+		NewMapKeyField(123456, "map", min5)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"map": []string{"min-5"},
+	})
+
+	m := map[int]int{1: 100, 20: 2, 6: 6}
+	v = New(ContinueAtError, 10).
+		NewMapKeyField(m, "map", min5).
+		NewMapField(m, "map", max50)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"map[1]#": []string{"min-5"},
+		"map[1]":  []string{"max-50"},
+	})
+
+	v = New(ContinueAtError, 10, WithPath(JSONPointer)).
+		NewMapKeyField(m, "map", min5).
+		NewMapField(m, "map", max50)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"/map/1": []string{"min-5", "max-50"},
+	})
+
+	v = New(ExitAtError, 10).
+		NewMapKeyField(map[float64]int{1.5: 100, 0.5: 2, 6: 6}, "map", min5)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"map[0.5]#": []string{"min-5"},
+	})
+
+	v = New(ContinueAtError, 10).
+		NewMapKeyField(map[any]int{1: 100, "2": 2, 6: 6, nil: 5}, "map", min5)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"map[1]#":     []string{"min-5"},
+		"map[2]#":     []string{"min-5"},
+		"map[<nil>]#": []string{"min-5"},
+	})
+}
+
+func TestValidation_OverrideErrorHandling(t *testing.T) {
 	a := assert.New(t, false)
 	p := message.NewPrinter(language.Chinese)
 
 	min18 := NewRule(validator.Min(18), "不能小于 18")
-	notEmpty := NewRule(validator.Required(true), "不能为空")
+	max10 := NewRule(validator.Max(10), "不能大于 10")
 
-	obj := &object{}
-	v := New(ContinueAtError, 1).
-		NewField(obj, "obj/age", min18).
-		When(obj.Age > 0, func(v *Validation) {
-			v.NewField(obj.Name, "obj/name", notEmpty)
+	v := New(ContinueAtError, 10).
+		NewField(20, "f1", min18, max10).
+		OverrideErrorHandling(ExitFieldAtError, func(v *Validation) {
+			v.NewField(5, "f2", max10, min18, min18).
+				NewField(20, "f3", min18, max10)
+		}).
+		NewField(5, "f4", min18, min18)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f1": {"不能大于 10"},
+		"f2": {"不能小于 18"},
+		"f3": {"不能大于 10"},
+		"f4": {"不能小于 18", "不能小于 18"},
+	})
+	a.Equal(v.errHandling, ContinueAtError)
+
+	v = New(ExitFieldAtError, 10).
+		OverrideErrorHandling(ContinueAtError, func(v *Validation) {
+			v.NewField(5, "f1", min18, min18)
+		}).
+		NewField(5, "f2", min18, min18)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f1": {"不能小于 18", "不能小于 18"},
+		"f2": {"不能小于 18"},
+	})
+
+	// ExitAtError 且已经有错误信息
+	v = New(ExitAtError, 10).
+		NewField(5, "f1", min18).
+		OverrideErrorHandling(ContinueAtError, func(v *Validation) {
+			v.NewField(5, "f2", min18)
 		})
 	a.Equal(v.LocaleMessages(p), LocaleMessages{
-		"obj/age": {"不能小于 18"},
+		"f1": {"不能小于 18"},
+	})
+}
+
+func TestValidation_Nested(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	required := NewRule(validator.Required(false), "required")
+
+	v := New(ContinueAtError, 10).
+		NewField("", "name", required).
+		Nested("address", func(v *Validation) {
+			v.NewField("", "city", required).
+				NewSliceField([]string{"", "1"}, "tags", required).
+				NewMapField(map[string]string{"k": ""}, "map", required).
+				Nested("", func(v *Validation) {
+					v.NewField("", "street", required)
+				})
+		}).
+		NewField("", "email", required)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"name":            {"required"},
+		"address.city":    {"required"},
+		"address.tags[0]": {"required"},
+		"address.map[k]":  {"required"},
+		"address.street":  {"required"},
+		"email":           {"required"},
 	})
 
-	obj = &object{Age: 15}
-	v = New(ContinueAtError, 1).
-		NewField(obj, "obj/age", min18).
-		When(obj.Age > 0, func(v *Validation) {
-			v.NewField(obj.Name, "obj/name", notEmpty)
+	v = New(ExitAtError, 10).
+		NewField("", "name", required).
+		Nested("address", func(v *Validation) {
+			v.NewField("", "city", required)
 		})
 	a.Equal(v.LocaleMessages(p), LocaleMessages{
-		"obj/age":  {"不能小于 18"},
-		"obj/name": {"不能为空"},
+		"name": {"required"},
+	})
+}
+
+func TestValidation_FieldsValidator(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	u := &user{Addresses: []*address{{City: "city", Tags: []string{"1", "22"}}, nil, {}}}
+	v := New(ContinueAtError, 10).NewField(u, "user")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"user.name":                 {"required"},
+		"user.addresses[0].tags[0]": {"min-length"},
+		"user.addresses[2].city":    {"required"},
+	})
+
+	// 规则未通过，不会验证子字段
+	v = New(ContinueAtError, 10).NewField(u, "user", NewRule(validator.Required(false), "required"), NewRule(validator.Min(5), "min-5"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"user": {"min-5"},
+	})
+
+	// nil
+	var nilUser *user
+	v = New(ContinueAtError, 10).NewField(nilUser, "user")
+	a.Empty(v.Messages())
+
+	// ExitFieldAtError 子字段出错会中断数组的验证
+	v = New(ExitFieldAtError, 10).NewSliceField([]*address{{}, {}}, "addresses")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"addresses[0].city": {"required"},
+	})
+}
+
+func TestWithPath(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	u := &user{Addresses: []*address{{City: "city", Tags: []string{"1", "22"}}, nil, {}}}
+
+	v := New(ContinueAtError, 10, WithPath(SlashPath)).NewField(u, "user")
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"user/name":               {"required"},
+		"user/addresses/0/tags/0": {"min-length"},
+		"user/addresses/2/city":   {"required"},
+	})
+
+	v = New(ContinueAtError, 10, WithPath(JSONPointer)).
+		NewField(u, "user").
+		NewMapField(map[string]int{"a/b": 1}, "map", NewRule(validator.Min(5), "min-5")).
+		NewGroupField(map[string]any{"f1": "", "f2": ""}, "", NewRule(validator.AtLeastOne, "at-least-one"))
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"/user/name":               {"required"},
+		"/user/addresses/0/tags/0": {"min-length"},
+		"/user/addresses/2/city":   {"required"},
+		"/map/a~1b":                {"min-5"},
+		"/f1":                      {"at-least-one"},
+		"/f2":                      {"at-least-one"},
 	})
 }
 
@@ -234,3 +473,119 @@ func TestValidation_Locale(t *testing.T) {
 		"obj": {"cht"},
 	})
 }
+
+func TestValidation_NewGroupField(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	atLeastOne := NewRule(validator.AtLeastOne, "at-least-one")
+	exactlyOne := NewRule(validator.ExactlyOne, "exactly-one")
+	atMostOne := NewRule(validator.AtMostOne, "at-most-one")
+
+	v := New(ContinueAtError, 10).
+		NewGroupField(map[string]any{"mobile": "", "email": ""}, "contact", atLeastOne).
+		NewGroupField(map[string]any{"id_card": "1", "credit_code": "2"}, "", exactlyOne).
+		NewGroupField(map[string]any{"coupon": "", "discount": 0}, "", atMostOne)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"contact":     {"at-least-one"},
+		"id_card":     {"exactly-one"},
+		"credit_code": {"exactly-one"},
+	})
+
+	v = New(ContinueAtError, 10).
+		NewGroupField(map[string]any{"coupon": "1", "discount": 0.5}, "", atLeastOne, exactlyOne, atMostOne)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"coupon":   {"exactly-one", "at-most-one"},
+		"discount": {"exactly-one", "at-most-one"},
+	})
+
+	v = New(ExitFieldAtError, 10).
+		NewGroupField(map[string]any{"coupon": "1", "discount": 0.5}, "group", atLeastOne, exactlyOne, atMostOne)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"group": {"exactly-one"},
+	})
+
+	v = New(ExitAtError, 10).
+		NewField(5, "f1", NewRule(validator.Min(10), "min-10")).
+		NewGroupField(map[string]any{"coupon": "1", "discount": 0.5}, "group", atMostOne)
+	a.Equal(v.LocaleMessages(p), LocaleMessages{
+		"f1": {"min-10"},
+	})
+}
+
+func TestValidation_NewUnionField(t *testing.T) {
+	a := assert.New(t, false)
+	p := message.NewPrinter(language.Chinese)
+
+	type object struct {
+		Type       any
+		IDCard     string
+		CreditCode string
+	}
+
+	validate := func(o *object, errHandling ErrorHandling) LocaleMessages {
+		return New(errHandling, 10).
+			Nested("obj", func(v *Validation) {
+				v.NewUnionField(o.Type, "type", localeutil.Phrase("unknown"), map[any]func(*Validation){
+					"person": func(v *Validation) {
+						v.NewField(o.IDCard, "id_card", NewRule(validator.GB11643, "gb11643"))
+					},
+					"company": func(v *Validation) {
+						v.NewField(o.CreditCode, "credit_code", NewRule(validator.GB32100, "gb32100"))
+					},
+				})
+			}).
+			NewField(0, "f1", NewRule(validator.Min(1), "min")).
+			LocaleMessages(p)
+	}
+
+	a.Equal(validate(&object{Type: "person", CreditCode: "invalid"}, ContinueAtError), LocaleMessages{
+		"obj.id_card": {"gb11643"},
+		"f1":          {"min"},
+	})
+	a.Equal(validate(&object{Type: "person", IDCard: "513330199111066159"}, ContinueAtError), LocaleMessages{
+		"f1": {"min"},
+	})
+	a.Equal(validate(&object{Type: "company", IDCard: "invalid"}, ContinueAtError), LocaleMessages{
+		"obj.credit_code": {"gb32100"},
+		"f1":              {"min"},
+	})
+	a.Equal(validate(&object{Type: "company", CreditCode: "91350100M000100Y43"}, ExitAtError), LocaleMessages{
+		"f1": {"min"},
+	})
+
+	// 未知的类型
+	a.Equal(validate(&object{Type: "other"}, ExitAtError), LocaleMessages{
+		"obj.type": {"unknown"},
+	})
+	a.Equal(validate(&object{}, ContinueAtError), LocaleMessages{
+		"obj.type": {"unknown"},
+		"f1":       {"min"},
+	})
+	a.Equal(validate(&object{Type: []string{"person"}}, ContinueAtError), LocaleMessages{
+		"obj.type": {"unknown"},
+		"f1":       {"min"},
+	})
+	a.Equal(validate(&object{Type: [1]any{[]int{1}}}, ContinueAtError), LocaleMessages{
+		"obj.type": {"unknown"},
+		"f1":       {"min"},
+	})
+
+	// 类型字段不存在时，依然执行相匹配的分支
+	union := func(typ any) LocaleMessages {
+		return New(ContinueAtError, 10, WithPresent("id_card")).
+			NewUnionField(typ, "type", localeutil.Phrase("unknown"), map[any]func(*Validation){
+				"person": func(v *Validation) {
+					v.NewField("", "id_card", NewRule(validator.Required(false), "required"))
+				},
+				"company": func(v *Validation) {
+					v.NewField("", "credit_code", NewRule(validator.Required(false), "required"))
+				},
+			}).LocaleMessages(p)
+	}
+	a.Equal(union("person"), LocaleMessages{
+		"id_card": {"required"},
+	})
+	a.Empty(union("company"))
+	a.Empty(union("other"))
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"reflect"
+
+	"github.com/issue9/validation/is"
+)
+
+// 对 NotEmptyCount 的简单封装
+var (
+	AtLeastOne = NotEmptyCount(1, -1) // 至少有一个元素不为空
+	ExactlyOne = NotEmptyCount(1, 1)  // 有且只有一个元素不为空
+	AtMostOne  = NotEmptyCount(-1, 1) // 最多只有一个元素不为空，即各元素之间互斥
+)
+
+// NotEmptyCount 声明判断非空元素数量的验证规则
+//
+// 统计数组或切片中不为空的元素数量，要求其在 [min,max] 之间，
+// 如果 min 和 max 有值为 -1，表示忽略该值的比较。
+// 元素是否为空的判断规则可参考 github.com/issue9/validation/is.Empty。
+//
+// 只能验证类型为 Slice 和 Array 的数据。
+func NotEmptyCount(min, max int) ValidateFunc {
+	if min > 0 && max > 0 && min > max {
+		panic("max 必须大于 min")
+	}
+
+	return func(v any) bool {
+		rv := reflect.ValueOf(v)
+		if kind := rv.Kind(); kind != reflect.Array && kind != reflect.Slice {
+			return false
+		}
+
+		var count int
+		for i := 0; i < rv.Len(); i++ {
+			if !is.Empty(rv.Index(i).Interface(), false) {
+				count++
+			}
+		}
+
+		if min >= 0 && count < min {
+			return false
+		}
+		return max < 0 || count <= max
+	}
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+)
+
+func TestNotEmptyCount(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Panic(func() {
+		NotEmptyCount(5, 1)
+	})
+
+	r := NotEmptyCount(1, 2)
+	a.False(r.IsValid(5))
+	a.False(r.IsValid([]any{"", 0, nil}))
+	a.True(r.IsValid([]any{"1", 0, nil}))
+	a.True(r.IsValid([2]any{"1", 1}))
+	a.False(r.IsValid([]any{"1", 1, []int{1}}))
+
+	a.False(AtLeastOne.IsValid([]any{"", 0}))
+	a.True(AtLeastOne.IsValid([]any{"", 1}))
+	a.True(AtLeastOne.IsValid([]any{"1", 1}))
+
+	a.False(ExactlyOne.IsValid([]any{"", 0}))
+	a.True(ExactlyOne.IsValid([]any{"", 1}))
+	a.False(ExactlyOne.IsValid([]any{"1", 1}))
+
+	a.True(AtMostOne.IsValid([]any{"", 0}))
+	a.True(AtMostOne.IsValid([]any{"", 1}))
+	a.False(AtMostOne.IsValid([]any{"1", 1}))
+}
@@ -2,7 +2,26 @@
 
 package validator
 
-import "reflect"
+import (
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// 字符串长度的计算方式
+const (
+	ByteUnit     LengthUnit = iota // 按字节计算，"中文" 的长度为 6
+	RuneUnit                       // 按 Unicode 字符计算，"中文" 的长度为 2
+	GraphemeUnit                   // 按用户感知的字符计算，组合字符和 emoji 序列等都只算一个字符
+	WidthUnit                      // 按东亚文字的显示宽度计算，全角和宽字符计为 2，"中文" 的长度为 4
+)
+
+const zwj = '\u200d' // 零宽连接符
+
+// LengthUnit 字符串长度的计算方式
+type LengthUnit int8
 
 // MinLength 声明判断内容长度不小于 min 的验证规则
 func MinLength(min int64) ValidateFunc { return Length(min, -1) }
@@ -15,7 +34,59 @@ func MaxLength(max int64) ValidateFunc { return Length(-1, max) }
 // 如果 min 和 max 有值为 -1，表示忽略该值的比较，都为 -1 表示不限制长度。
 //
 // 只能验证类型为 string、Map、Slice 和 Array 的数据。
+// 其中 string 按字节计算长度，如果需要按字符计算，可以使用 StringLength。
 func Length(min, max int64) ValidateFunc {
+	return length(min, max, func(v any) (int64, bool) {
+		switch vv := v.(type) {
+		case string:
+			return int64(len(vv)), true
+		default:
+			rv := reflect.ValueOf(v)
+			switch rv.Kind() {
+			case reflect.Array, reflect.Map, reflect.Slice:
+				return int64(rv.Len()), true
+			default:
+				return 0, false
+			}
+		}
+	})
+}
+
+// StringLength 声明以 unit 的方式判断字符串长度的验证规则
+//
+// 如果 min 和 max 有值为 -1，表示忽略该值的比较，都为 -1 表示不限制长度。
+//
+// 只能验证类型为 string、[]byte 和 []rune 的数据。
+func StringLength(unit LengthUnit, min, max int64) ValidateFunc {
+	var count func(string) int64
+	switch unit {
+	case ByteUnit:
+		count = func(s string) int64 { return int64(len(s)) }
+	case RuneUnit:
+		count = func(s string) int64 { return int64(utf8.RuneCountInString(s)) }
+	case GraphemeUnit:
+		count = graphemeCount
+	case WidthUnit:
+		count = displayWidth
+	default:
+		panic("无效的参数 unit")
+	}
+
+	return length(min, max, func(v any) (int64, bool) {
+		switch vv := v.(type) {
+		case string:
+			return count(vv), true
+		case []byte:
+			return count(string(vv)), true
+		case []rune:
+			return count(string(vv)), true
+		default:
+			return 0, false
+		}
+	})
+}
+
+func length(min, max int64, size func(any) (int64, bool)) ValidateFunc {
 	if min > 0 && max > 0 && min > max {
 		panic("max 必须大于 min")
 	}
@@ -25,18 +96,9 @@ func Length(min, max int64) ValidateFunc {
 			return true
 		}
 
-		var l int64
-		switch vv := v.(type) {
-		case string:
-			l = int64(len(vv))
-		default:
-			rv := reflect.ValueOf(v)
-			switch rv.Kind() {
-			case reflect.Array, reflect.Map, reflect.Slice:
-				l = int64(rv.Len())
-			default:
-				return false
-			}
+		l, ok := size(v)
+		if !ok {
+			return false
 		}
 
 		if min < 0 {
@@ -48,3 +110,69 @@ func Length(min, max int64) ValidateFunc {
 		return l >= min && l <= max
 	}
 }
+
+// 计算字符串中用户感知的字符数量
+//
+// 这是对 Unicode 字素簇规则的简化实现，以下情况会与前一个字符合并计算：
+// 组合用字符、变体选择符、emoji 肤色修饰符、标签字符、ZWJ 及其之后的字符、
+// 成对出现的区域指示符以及 \r\n。
+func graphemeCount(s string) int64 {
+	var count int64
+	var prev rune = -1
+	var joined bool // 前一个字符是否为 ZWJ
+	var regional int
+
+	for _, r := range s {
+		extend := prev != -1 &&
+			(joined ||
+				(r == '\n' && prev == '\r') ||
+				isGraphemeExtend(r) ||
+				(isRegionalIndicator(r) && regional%2 == 1))
+		if !extend {
+			count++
+		}
+
+		if isRegionalIndicator(r) {
+			regional++
+		} else {
+			regional = 0
+		}
+		joined = r == zwj
+		prev = r
+	}
+
+	return count
+}
+
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == zwj ||
+		(r >= 0xfe00 && r <= 0xfe0f) || // 变体选择符
+		(r >= 0xe0100 && r <= 0xe01ef) ||
+		(r >= 0x1f3fb && r <= 0x1f3ff) || // emoji 肤色修饰符
+		(r >= 0xe0020 && r <= 0xe007f) // 标签字符
+}
+
+func isWide(r rune) bool {
+	k := width.LookupRune(r).Kind()
+	return k == width.EastAsianWide || k == width.EastAsianFullwidth
+}
+
+func isRegionalIndicator(r rune) bool { return r >= 0x1f1e6 && r <= 0x1f1ff }
+
+// 计算字符串的显示宽度
+//
+// 全角和宽字符计为 2，组合用字符和 ZWJ 等不占宽度的字符计为 0，其它字符计为 1。
+func displayWidth(s string) int64 {
+	var w int64
+	for _, r := range s {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me) || r == zwj:
+		case isWide(r):
+			w += 2
+		default:
+			w++
+		}
+	}
+	return w
+}
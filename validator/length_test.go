@@ -36,3 +36,48 @@ func TestLength(t *testing.T) {
 	a.False(l.IsValid("12345678910"))
 	a.True(l.IsValid("12345"))
 }
+
+func TestStringLength(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Panic(func() {
+		StringLength(RuneUnit, 500, 50)
+	})
+
+	a.Panic(func() {
+		StringLength(100, 5, 50)
+	})
+
+	l := StringLength(ByteUnit, 5, 6)
+	a.True(l.IsValid("中文"))
+	a.False(l.IsValid("中文中文"))
+	a.False(l.IsValid(5))
+
+	l = StringLength(RuneUnit, 2, 3)
+	a.True(l.IsValid("中文"))
+	a.True(l.IsValid([]byte("中文")))
+	a.True(l.IsValid([]rune("中文")))
+	a.True(l.IsValid("ab"))
+	a.False(l.IsValid("中文中文"))
+	a.False(l.IsValid([]int{1, 2}))
+
+	l = StringLength(GraphemeUnit, 2, 2)
+	a.True(l.IsValid("中文"))
+	a.True(l.IsValid("e\u0301a"))                                    // 组合字符
+	a.True(l.IsValid("\U0001f44d\U0001f3fda"))                       // 肤色修饰符
+	a.True(l.IsValid("\U0001f468\u200d\U0001f469\u200d\U0001f467a")) // ZWJ 序列
+	a.True(l.IsValid("\U0001f1e8\U0001f1f3\U0001f1fa\U0001f1f8"))    // 区域指示符
+	a.True(l.IsValid("\U0001f1e8\U0001f1f3\U0001f1fa"))              // 多余的区域指示符单独计算
+	a.True(l.IsValid("\r\na"))                                       // CRLF
+	a.True(l.IsValid("\u2764\ufe0fa"))                               // 变体选择符
+	a.False(l.IsValid("e\u0301ab"))
+	a.False(StringLength(GraphemeUnit, 1, 1).IsValid(""))
+
+	l = StringLength(WidthUnit, -1, 4)
+	a.True(l.IsValid("中文"))
+	a.True(l.IsValid("１ab"))
+	a.True(l.IsValid("abcd"))
+	a.True(l.IsValid("ae\u0301c"))
+	a.False(l.IsValid("中文a"))
+	a.False(l.IsValid("１２３"))
+}
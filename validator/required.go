@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"reflect"
+
+	"github.com/issue9/sliceutil"
+
+	"github.com/issue9/validation/is"
+)
+
+// RequiredIf 当 other 的值为 values 中的任意一个时，要求当前值不能为空
+//
+// skipNil 的功能与 Required 相同。
+func RequiredIf(skipNil bool, other any, values ...any) ValidateFunc {
+	if !in(other, values) {
+		return alwaysValid
+	}
+	return Required(skipNil)
+}
+
+// RequiredUnless 除非 other 的值为 values 中的任意一个，否则要求当前值不能为空
+//
+// skipNil 的功能与 Required 相同。
+func RequiredUnless(skipNil bool, other any, values ...any) ValidateFunc {
+	if in(other, values) {
+		return alwaysValid
+	}
+	return Required(skipNil)
+}
+
+// RequiredWith 当 others 中的任意一个值不为空时，要求当前值不能为空
+//
+// skipNil 的功能与 Required 相同。
+func RequiredWith(skipNil bool, others ...any) ValidateFunc {
+	if !sliceutil.Exists(others, func(o any) bool { return !is.Empty(o, false) }) {
+		return alwaysValid
+	}
+	return Required(skipNil)
+}
+
+// RequiredWithout 当 others 中的任意一个值为空时，要求当前值不能为空
+//
+// skipNil 的功能与 Required 相同。
+func RequiredWithout(skipNil bool, others ...any) ValidateFunc {
+	if !sliceutil.Exists(others, func(o any) bool { return is.Empty(o, false) }) {
+		return alwaysValid
+	}
+	return Required(skipNil)
+}
+
+// ExcludedIf 当 other 的值为 values 中的任意一个时，要求当前值必须为空
+func ExcludedIf(other any, values ...any) ValidateFunc {
+	if !in(other, values) {
+		return alwaysValid
+	}
+	return func(v any) bool { return is.Empty(v, false) }
+}
+
+func alwaysValid(any) bool { return true }
+
+func in(v any, values []any) bool {
+	return sliceutil.Exists(values, func(e any) bool { return equal(e, v) })
+}
+
+// 判断 a 与 b 是否相等
+//
+// 即使类型本身是可比较的，比如包含接口字段的结构体，实际的值依然可能无法使用 == 进行比较，
+// 此时改用 reflect.DeepEqual 进行比较。
+func equal(a, b any) (eq bool) {
+	defer func() {
+		if recover() != nil {
+			eq = reflect.DeepEqual(a, b)
+		}
+	}()
+	return a == b
+}
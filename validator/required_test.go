@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+)
+
+func TestRequiredIf(t *testing.T) {
+	a := assert.New(t, false)
+
+	r := RequiredIf(false, "person", "person", "company")
+	a.False(r.IsValid(""))
+	a.False(r.IsValid(nil))
+	a.True(r.IsValid("123"))
+
+	r = RequiredIf(true, "person", "person", "company")
+	a.True(r.IsValid(nil))
+	a.False(r.IsValid(""))
+
+	r = RequiredIf(false, "other", "person", "company")
+	a.True(r.IsValid(""))
+	a.True(r.IsValid(nil))
+
+	r = RequiredIf(false, 1, "1")
+	a.True(r.IsValid(""))
+
+	// 不可比较的类型
+	a.NotPanic(func() {
+		r = RequiredIf(false, []int{1}, []int{1})
+	})
+	a.False(r.IsValid(""))
+	r = RequiredIf(false, []int{1}, []int{2}, "1")
+	a.True(r.IsValid(""))
+
+	// 类型可比较，但实际的值不可比较
+	a.NotPanic(func() {
+		r = RequiredIf(false, [1]any{[]int{1}}, [1]any{[]int{1}})
+	})
+	a.False(r.IsValid(""))
+	type object struct{ X any }
+	a.NotPanic(func() {
+		r = RequiredIf(false, object{X: []int{1}}, object{X: []int{2}})
+	})
+	a.True(r.IsValid(""))
+}
+
+func TestRequiredUnless(t *testing.T) {
+	a := assert.New(t, false)
+
+	r := RequiredUnless(false, "person", "person", "company")
+	a.True(r.IsValid(""))
+	a.True(r.IsValid("123"))
+
+	r = RequiredUnless(false, "other", "person", "company")
+	a.False(r.IsValid(""))
+	a.True(r.IsValid("123"))
+}
+
+func TestRequiredWith(t *testing.T) {
+	a := assert.New(t, false)
+
+	r := RequiredWith(false, "", 0, nil)
+	a.True(r.IsValid(""))
+
+	r = RequiredWith(false, "", 5, nil)
+	a.False(r.IsValid(""))
+	a.True(r.IsValid("123"))
+
+	r = RequiredWith(false)
+	a.True(r.IsValid(""))
+}
+
+func TestRequiredWithout(t *testing.T) {
+	a := assert.New(t, false)
+
+	r := RequiredWithout(false, "1", 5)
+	a.True(r.IsValid(""))
+
+	r = RequiredWithout(false, "1", 0)
+	a.False(r.IsValid(""))
+	a.True(r.IsValid("123"))
+}
+
+func TestExcludedIf(t *testing.T) {
+	a := assert.New(t, false)
+
+	r := ExcludedIf("person", "person")
+	a.True(r.IsValid(""))
+	a.True(r.IsValid(nil))
+	a.False(r.IsValid("123"))
+
+	r = ExcludedIf("company", "person")
+	a.True(r.IsValid("123"))
+}
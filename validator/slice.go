@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import "reflect"
+
+var (
+	// Unique 判断数组中的元素是否都不相同
+	//
+	// 只能验证类型为 Slice 和 Array 的数据。
+	Unique = ValidateFunc(unique)
+
+	// Sorted 判断数组中的元素是否按从小到大的顺序排列
+	//
+	// 只能验证元素类型为数值或字符串的 Slice 和 Array。
+	Sorted = ValidateFunc(sorted)
+)
+
+func unique(v any) bool {
+	rv, ok := sliceValue(v)
+	if !ok {
+		return false
+	}
+
+	// 元素中包含接口时，实际的值未必可以作为 map 的键名，
+	// 可以作为键名的值通过 map 查找，其它的值则两两之间通过 reflect.DeepEqual 进行比较。
+	exists := make(map[any]struct{}, rv.Len())
+	others := make([]any, 0, 5)
+	for i := 0; i < rv.Len(); i++ {
+		elem := rv.Index(i).Interface()
+		if found, ok := addKey(exists, elem); ok {
+			if found {
+				return false
+			}
+			continue
+		}
+
+		for _, other := range others {
+			if reflect.DeepEqual(elem, other) {
+				return false
+			}
+		}
+		others = append(others, elem)
+	}
+	return true
+}
+
+// 将 key 添加到 m 中并返回其之前是否已经存在
+//
+// ok 表示 key 能否作为 map 的键名。
+func addKey(m map[any]struct{}, key any) (found, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	if _, found = m[key]; !found {
+		m[key] = struct{}{}
+	}
+	return found, true
+}
+
+func sorted(v any) bool {
+	rv, ok := sliceValue(v)
+	if !ok {
+		return false
+	}
+
+	var less func(i, j reflect.Value) bool
+	switch rv.Type().Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(i, j reflect.Value) bool { return i.Int() < j.Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		less = func(i, j reflect.Value) bool { return i.Uint() < j.Uint() }
+	case reflect.Float32, reflect.Float64:
+		less = func(i, j reflect.Value) bool { return i.Float() < j.Float() }
+	case reflect.String:
+		less = func(i, j reflect.Value) bool { return i.String() < j.String() }
+	default:
+		return false
+	}
+
+	for i := 1; i < rv.Len(); i++ {
+		if less(rv.Index(i), rv.Index(i-1)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains 声明判断数组是否包含指定元素的验证规则
+//
+// 要求验证的数组必须包含 element 中的所有元素，只能验证类型为 Slice 和 Array 的数据。
+func Contains[T comparable](element ...T) ValidateFunc {
+	return func(v any) bool {
+		rv, ok := sliceValue(v)
+		if !ok {
+			return false
+		}
+
+	LOOP:
+		for _, elem := range element {
+			for i := 0; i < rv.Len(); i++ {
+				if rv.Index(i).Interface() == any(elem) {
+					continue LOOP
+				}
+			}
+			return false
+		}
+		return true
+	}
+}
+
+func sliceValue(v any) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	kind := rv.Kind()
+	return rv, kind == reflect.Slice || kind == reflect.Array
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v2"
+)
+
+func TestUnique(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.False(Unique(5))
+	a.True(Unique([]int{}))
+	a.True(Unique([]int{1, 2, 3}))
+	a.False(Unique([]int{1, 2, 1}))
+	a.True(Unique([3]string{"1", "2", "3"}))
+	a.False(Unique([]any{"1", 2, "1"}))
+	a.True(Unique([]any{"1", 1}))
+	a.True(Unique([][]int{{1}, {2}}))
+	a.False(Unique([][]int{{1}, {2}, {1}}))
+
+	// 元素为接口，但实际的值不可比较
+	a.False(Unique([]any{[]int{1}, []int{1}}))
+	a.True(Unique([]any{[]int{1}, []int{2}, 1, nil}))
+	a.False(Unique([]any{nil, 1, nil}))
+	a.False(Unique([]any{map[string]int{"1": 1}, "1", map[string]int{"1": 1}}))
+
+	// 元素类型可比较，但其中接口字段的值不可比较
+	type object struct{ X any }
+	a.False(Unique([]object{{X: []int{1}}, {X: 1}, {X: []int{1}}}))
+	a.True(Unique([]object{{X: []int{1}}, {X: 1}, {X: []int{2}}}))
+	a.False(Unique([][1]any{{[]int{1}}, {[]int{1}}}))
+}
+
+func TestSorted(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.False(Sorted(5))
+	a.True(Sorted([]int{}))
+	a.True(Sorted([]int{1, 2, 2, 3}))
+	a.False(Sorted([]int{1, 3, 2}))
+	a.True(Sorted([]uint8{1, 2, 3}))
+	a.True(Sorted([]float32{1.1, 2.1, 3}))
+	a.False(Sorted([]float64{1.1, 0.2}))
+	a.True(Sorted([]string{"a", "b", "c"}))
+	a.False(Sorted([]string{"b", "a"}))
+	a.False(Sorted([]any{1, 2}))
+}
+
+func TestContains(t *testing.T) {
+	a := assert.New(t, false)
+
+	r := Contains(1, 2)
+	a.False(r.IsValid(1))
+	a.True(r.IsValid([]int{1, 2, 3}))
+	a.True(r.IsValid([]any{2, 1}))
+	a.False(r.IsValid([]int{1, 3}))
+	a.False(r.IsValid([]int8{1, 2}))
+
+	r = Contains[string]()
+	a.True(r.IsValid([]string{}))
+}